@@ -0,0 +1,15 @@
+package auth
+
+// The AuthenticationError is returned when the authenticator could not
+// retrieve the credentials for the request.
+type AuthenticationError struct {
+	message string
+}
+
+func (e AuthenticationError) Error() string {
+	return e.message
+}
+
+func NewAuthenticationError(message string) *AuthenticationError {
+	return &AuthenticationError{message}
+}
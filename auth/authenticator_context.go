@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"github.com/UiPath/uipathcli/log"
+	"github.com/UiPath/uipathcli/utils/network"
 )
 
 // AuthenticatorContext provides information required for authenticating requests.
@@ -12,6 +13,9 @@ type AuthenticatorContext struct {
 	IdentityUri url.URL
 	OperationId string
 	Insecure    bool
+	RateLimit   network.RateLimitSettings
+	Connection  network.ConnectionSettings
+	Tracer      *network.Tracer
 	Debug       bool
 	Request     AuthenticatorRequest
 	Logger      log.Logger
@@ -22,6 +26,9 @@ func NewAuthenticatorContext(
 	identityUri url.URL,
 	operationId string,
 	insecure bool,
+	rateLimit network.RateLimitSettings,
+	connection network.ConnectionSettings,
+	tracer *network.Tracer,
 	debug bool,
 	request AuthenticatorRequest,
 	logger log.Logger,
@@ -31,6 +38,9 @@ func NewAuthenticatorContext(
 		identityUri,
 		operationId,
 		insecure,
+		rateLimit,
+		connection,
+		tracer,
 		debug,
 		request,
 		logger,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/UiPath/uipathcli/cache"
 	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/resiliency"
 )
 
 const GetTokenTimeout = time.Duration(60) * time.Second
@@ -185,7 +186,11 @@ func (a BearerAuthenticator) networkSettings(ctx AuthenticatorContext) network.H
 		ctx.OperationId,
 		map[string]string{},
 		GetTokenTimeout,
-		GetTokenMaxAttempts,
+		*resiliency.NewDefaultRetryPolicy(GetTokenMaxAttempts),
+		true,
+		ctx.RateLimit,
+		ctx.Connection,
+		ctx.Tracer,
 		ctx.Insecure,
 	)
 }
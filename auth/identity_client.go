@@ -106,7 +106,11 @@ func (c identityClient) networkSettingsNoDebug(settings network.HttpClientSettin
 		settings.OperationId,
 		settings.Header,
 		settings.Timeout,
-		settings.MaxAttempts,
+		settings.Retry,
+		settings.RetryNonIdempotent,
+		settings.RateLimit,
+		settings.Connection,
+		settings.Tracer,
 		settings.Insecure,
 	)
 }
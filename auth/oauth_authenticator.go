@@ -12,6 +12,7 @@ import (
 
 	"github.com/UiPath/uipathcli/cache"
 	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/resiliency"
 )
 
 const RedirectUriVarName = "UIPATH_AUTH_REDIRECT_URI"
@@ -309,7 +310,11 @@ func (a OAuthAuthenticator) networkSettings(ctx AuthenticatorContext) network.Ht
 		ctx.OperationId,
 		map[string]string{},
 		GetTokenTimeout,
-		GetTokenMaxAttempts,
+		*resiliency.NewDefaultRetryPolicy(GetTokenMaxAttempts),
+		true,
+		ctx.RateLimit,
+		ctx.Connection,
+		ctx.Tracer,
 		ctx.Insecure,
 	)
 }
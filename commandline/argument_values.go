@@ -0,0 +1,10 @@
+package commandline
+
+// The argumentValues interface provides access to the values of the command
+// arguments. The values are typically provided on the command line but can also
+// come from other sources like the rows of a batch input file.
+type argumentValues interface {
+	IsSet(name string) bool
+	String(name string) string
+	StringSlice(name string) []string
+}
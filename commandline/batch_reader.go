@@ -0,0 +1,159 @@
+package commandline
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/UiPath/uipathcli/utils/stream"
+)
+
+const batchMaxLineSize = 10 * 1024 * 1024
+
+// The batchReader parses the batch input file which is provided using the
+// --batch argument. Every line of a JSONL file or every row of a CSV file
+// contains the argument values for a single operation call.
+//
+// JSONL Example:
+//
+//	{"name": "asset1", "value": "my-value"}
+//	{"name": "asset2", "value": "my-other-value"}
+//
+// CSV Example:
+//
+//	name,value
+//	asset1,my-value
+//	asset2,my-other-value
+type batchReader struct{}
+
+func (r batchReader) Read(input stream.Stream, fallback argumentValues) ([]batchRow, error) {
+	data, err := input.Data()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = data.Close() }()
+
+	if strings.EqualFold(filepath.Ext(input.Name()), ".csv") {
+		return r.readCsv(data, fallback)
+	}
+	return r.readJsonLines(data, fallback)
+}
+
+func (r batchReader) readCsv(data io.Reader, fallback argumentValues) ([]batchRow, error) {
+	reader := csv.NewReader(data)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []batchRow{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid batch input: %w", err)
+	}
+
+	rows := []batchRow{}
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid batch input in row %d: %w", number, err)
+		}
+		values := map[string][]string{}
+		for i, name := range header {
+			if record[i] != "" {
+				values[strings.TrimSpace(name)] = []string{record[i]}
+			}
+		}
+		rows = append(rows, *newBatchRow(number, values, fallback))
+	}
+	return rows, nil
+}
+
+func (r batchReader) readJsonLines(data io.Reader, fallback argumentValues) ([]batchRow, error) {
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 64*1024), batchMaxLineSize)
+
+	rows := []batchRow{}
+	number := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		number++
+		values, err := r.parseJsonLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid batch input in row %d: %w", number, err)
+		}
+		rows = append(rows, *newBatchRow(number, values, fallback))
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Error reading batch input: %w", err)
+	}
+	return rows, nil
+}
+
+func (r batchReader) parseJsonLine(line string) (map[string][]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var data map[string]interface{}
+	err := decoder.Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string][]string{}
+	for name, value := range data {
+		if value == nil {
+			continue
+		}
+		converted, err := r.convertJsonValue(value)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = converted
+	}
+	return values, nil
+}
+
+func (r batchReader) convertJsonValue(value interface{}) ([]string, error) {
+	array, ok := value.([]interface{})
+	if !ok {
+		item, err := r.convertJsonItem(value)
+		return []string{item}, err
+	}
+	result := []string{}
+	for _, value := range array {
+		item, err := r.convertJsonItem(value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (r batchReader) convertJsonItem(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
+
+func newBatchReader() *batchReader {
+	return &batchReader{}
+}
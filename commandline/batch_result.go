@@ -0,0 +1,81 @@
+package commandline
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/UiPath/uipathcli/output"
+)
+
+const batchStatusSucceeded = "succeeded"
+const batchStatusFailed = "failed"
+
+// The batchRowResult contains the outcome of executing the operation for a
+// single row of the batch input.
+type batchRowResult struct {
+	Row        int         `json:"row"`
+	Status     string      `json:"status"`
+	StatusCode int         `json:"statusCode,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// The batchResult summarizes the execution of all rows of the batch input.
+type batchResult struct {
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []batchRowResult `json:"results"`
+}
+
+func newBatchRowError(row int, err error) *batchRowResult {
+	return &batchRowResult{
+		Row:    row,
+		Status: batchStatusFailed,
+		Error:  err.Error(),
+	}
+}
+
+func newBatchRowResult(row int, response output.ResponseInfo) *batchRowResult {
+	status := batchStatusSucceeded
+	if response.StatusCode != 0 && (response.StatusCode < 200 || response.StatusCode >= 300) {
+		status = batchStatusFailed
+	}
+	return &batchRowResult{
+		Row:        row,
+		Status:     status,
+		StatusCode: response.StatusCode,
+		Body:       parseBatchBody(response.Body),
+	}
+}
+
+func parseBatchBody(reader io.Reader) interface{} {
+	if reader == nil {
+		return nil
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil || len(body) == 0 {
+		return nil
+	}
+	var data interface{}
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return string(body)
+	}
+	return data
+}
+
+func newBatchResult(results []batchRowResult) *batchResult {
+	succeeded := 0
+	for _, result := range results {
+		if result.Status == batchStatusSucceeded {
+			succeeded++
+		}
+	}
+	return &batchResult{
+		Total:     len(results),
+		Succeeded: succeeded,
+		Failed:    len(results) - succeeded,
+		Results:   results,
+	}
+}
@@ -0,0 +1,48 @@
+package commandline
+
+import (
+	"strings"
+)
+
+// The batchRow contains the argument values of a single row of the batch input.
+//
+// Arguments which are not provided in the row fall back to the values provided
+// on the command line, e.g. a common --folder-id can be passed once for all rows.
+type batchRow struct {
+	Number   int
+	values   map[string][]string
+	fallback argumentValues
+}
+
+func (r batchRow) Names() []string {
+	names := []string{}
+	for name := range r.values {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (r batchRow) IsSet(name string) bool {
+	if _, found := r.values[name]; found {
+		return true
+	}
+	return r.fallback.IsSet(name)
+}
+
+func (r batchRow) String(name string) string {
+	if values, found := r.values[name]; found {
+		return strings.Join(values, ",")
+	}
+	return r.fallback.String(name)
+}
+
+func (r batchRow) StringSlice(name string) []string {
+	if values, found := r.values[name]; found {
+		return values
+	}
+	return r.fallback.StringSlice(name)
+}
+
+func newBatchRow(number int, values map[string][]string, fallback argumentValues) *batchRow {
+	return &batchRow{number, values, fallback}
+}
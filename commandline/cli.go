@@ -4,9 +4,11 @@ package commandline
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/UiPath/uipathcli/config"
@@ -71,7 +73,7 @@ func (c Cli) run(ctx context.Context, args []string, input stream.Stream) error
 			commandError = fmt.Errorf("Command '%s' not found", commandName)
 		},
 		OnUsageError: func(ctx context.Context, cmd *cli.Command, err error, isSubcommand bool) error {
-			return fmt.Errorf("Incorrect usage: %w", err)
+			return NewValidationError(fmt.Errorf("Incorrect usage: %w", err))
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.IsSet(FlagNameVersion) {
@@ -103,14 +105,43 @@ uipath <service> <operation> --<argument> <value>`)
 const colorRed = "\033[31m"
 const colorReset = "\033[0m"
 
+// errorFormat reads the --error-format argument directly from the command
+// line arguments because errors can occur before the arguments are parsed.
+func (c Cli) errorFormat(args []string) string {
+	prefix := "--" + FlagNameErrorFormat
+	for i, arg := range args {
+		arg = strings.TrimSpace(arg)
+		if arg == prefix && len(args) > i+1 {
+			return strings.TrimSpace(args[i+1])
+		}
+		if value, found := strings.CutPrefix(arg, prefix+"="); found {
+			return strings.TrimSpace(value)
+		}
+	}
+	return os.Getenv("UIPATH_ERROR_FORMAT")
+}
+
+func (c Cli) writeJsonError(err error) {
+	data, _ := json.Marshal(newErrorOutput(err))
+	_, _ = fmt.Fprintln(c.stdErr, string(data))
+}
+
+func (c Cli) writeError(err error) {
+	message := err.Error()
+	if c.coloredOutput {
+		message = colorRed + err.Error() + colorReset
+	}
+	_, _ = fmt.Fprintln(c.stdErr, message)
+}
+
 func (c Cli) Run(ctx context.Context, args []string, input stream.Stream) error {
 	err := c.run(ctx, args, input)
 	if err != nil {
-		message := err.Error()
-		if c.coloredOutput {
-			message = colorRed + err.Error() + colorReset
+		if c.errorFormat(args) == FlagValueErrorFormatJson {
+			c.writeJsonError(err)
+		} else {
+			c.writeError(err)
 		}
-		_, _ = fmt.Fprintln(c.stdErr, message)
 	}
 	return err
 }
@@ -146,7 +177,7 @@ func (c Cli) convertCommand(command *CommandDefinition) *cli.Command {
 			}
 		},
 		OnUsageError: func(ctx context.Context, cmd *cli.Command, err error, isSubcommand bool) error {
-			return fmt.Errorf("Incorrect usage: %w", err)
+			return NewValidationError(fmt.Errorf("Incorrect usage: %w", err))
 		},
 	}
 	if command.Action != nil {
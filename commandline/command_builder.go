@@ -1,15 +1,21 @@
 package commandline
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/UiPath/uipathcli/config"
@@ -17,6 +23,8 @@ import (
 	"github.com/UiPath/uipathcli/log"
 	"github.com/UiPath/uipathcli/output"
 	"github.com/UiPath/uipathcli/parser"
+	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/resiliency"
 	"github.com/UiPath/uipathcli/utils/stream"
 )
 
@@ -54,8 +62,8 @@ func (b CommandBuilder) fileInput(context *CommandExecContext, parameters []pars
 	return stream.NewFileStream(value)
 }
 
-func (b CommandBuilder) createExecutionParameter(context *CommandExecContext, config *config.Config, param parser.Parameter) (*executor.ExecutionParameter, error) {
-	typeConverter := newTypeConverter()
+func (b CommandBuilder) createExecutionParameter(context argumentValues, config *config.Config, param parser.Parameter) (*executor.ExecutionParameter, error) {
+	typeConverter := newTypeConverter(b.Input)
 	if context.IsSet(param.Name) && param.IsArray() {
 		value, err := typeConverter.ConvertArray(context.StringSlice(param.Name), param)
 		if err != nil {
@@ -80,7 +88,7 @@ func (b CommandBuilder) createExecutionParameter(context *CommandExecContext, co
 	return nil, nil
 }
 
-func (b CommandBuilder) createExecutionParameters(context *CommandExecContext, config *config.Config, operation parser.Operation) (executor.ExecutionParameters, error) {
+func (b CommandBuilder) createExecutionParameters(context argumentValues, config *config.Config, operation parser.Operation) (executor.ExecutionParameters, error) {
 	parameters := []executor.ExecutionParameter{}
 	for _, param := range operation.Parameters {
 		parameter, err := b.createExecutionParameter(context, config, param)
@@ -131,7 +139,7 @@ func (b CommandBuilder) sortParameters(parameters []parser.Parameter) {
 	})
 }
 
-func (b CommandBuilder) outputFormat(config config.Config, context *CommandExecContext) (string, error) {
+func (b CommandBuilder) outputSettings(config config.Config, context *CommandExecContext) (*outputSettings, error) {
 	outputFormat := context.String(FlagNameOutputFormat)
 	if outputFormat == "" {
 		outputFormat = config.Output
@@ -139,10 +147,79 @@ func (b CommandBuilder) outputFormat(config config.Config, context *CommandExecC
 	if outputFormat == "" {
 		outputFormat = FlagValueOutputFormatJson
 	}
-	if outputFormat != FlagValueOutputFormatJson && outputFormat != FlagValueOutputFormatText {
-		return "", fmt.Errorf("Invalid output format '%s', allowed values: %s, %s", outputFormat, FlagValueOutputFormatJson, FlagValueOutputFormatText)
+	if !slices.Contains(FlagValuesOutputFormat, outputFormat) {
+		return nil, fmt.Errorf("Invalid output format '%s', allowed values: %s", outputFormat, strings.Join(FlagValuesOutputFormat, ", "))
 	}
-	return outputFormat, nil
+	query := context.String(FlagNameQuery)
+	columns := b.parseColumns(context.String(FlagNameColumns))
+	var outputTemplate *template.Template
+	if outputFormat == FlagValueOutputFormatTemplate {
+		var err error
+		outputTemplate, err = b.parseTemplate(context)
+		if err != nil {
+			return nil, err
+		}
+	}
+	successOnly := context.String(FlagNameErrorFormat) == FlagValueErrorFormatJson && !context.Bool(FlagNameNoFailOnHttpError)
+	return newOutputSettings(outputFormat, query, columns, b.terminalWidth(), outputTemplate, successOnly), nil
+}
+
+func (b CommandBuilder) validateErrorFormat(context *CommandExecContext) error {
+	errorFormat := context.String(FlagNameErrorFormat)
+	if errorFormat != "" && !slices.Contains(FlagValuesErrorFormat, errorFormat) {
+		return fmt.Errorf("Invalid error format '%s', allowed values: %s", errorFormat, strings.Join(FlagValuesErrorFormat, ", "))
+	}
+	return nil
+}
+
+func (b CommandBuilder) parseTemplate(context *CommandExecContext) (*template.Template, error) {
+	text := context.String(FlagNameTemplate)
+	templateFile := context.String(FlagNameTemplateFile)
+	if text != "" && templateFile != "" {
+		return nil, fmt.Errorf("Only one of --%s or --%s can be provided", FlagNameTemplate, FlagNameTemplateFile)
+	}
+	if templateFile != "" {
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading template file '%s': %w", templateFile, err)
+		}
+		text = string(data)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("Argument --%s or --%s is required for %s output", FlagNameTemplate, FlagNameTemplateFile, FlagValueOutputFormatTemplate)
+	}
+	result, err := output.NewTemplate(text)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid template: %w", err)
+	}
+	return result, nil
+}
+
+func (b CommandBuilder) parseColumns(value string) []string {
+	columns := []string{}
+	for _, column := range strings.Split(value, ",") {
+		column = strings.TrimSpace(column)
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func (b CommandBuilder) terminalWidth() int {
+	file, ok := b.StdOut.(*os.File)
+	if !ok {
+		return 0
+	}
+	return terminalColumns(file)
+}
+
+func (b CommandBuilder) exportFormat(context *CommandExecContext) (string, error) {
+	exportFormat := context.String(FlagNameExport)
+	if exportFormat != "" && !slices.Contains(executor.ExportFormats, exportFormat) {
+		return "", fmt.Errorf("Invalid export format '%s', allowed values: %s", exportFormat, strings.Join(executor.ExportFormats, ", "))
+	}
+	return exportFormat, nil
 }
 
 func (b CommandBuilder) createBaseUri(operation parser.Operation, config config.Config, context *CommandExecContext) (url.URL, error) {
@@ -194,7 +271,7 @@ func (b CommandBuilder) parseUriArgument(context *CommandExecContext) (*url.URL,
 	return uriArgument, nil
 }
 
-func (b CommandBuilder) getValue(parameter parser.Parameter, context *CommandExecContext, config config.Config) string {
+func (b CommandBuilder) getValue(parameter parser.Parameter, context argumentValues, config config.Config) string {
 	value := context.String(parameter.Name)
 	if value != "" {
 		return value
@@ -213,7 +290,7 @@ func (b CommandBuilder) getValue(parameter parser.Parameter, context *CommandExe
 	return ""
 }
 
-func (b CommandBuilder) validateArguments(context *CommandExecContext, parameters []parser.Parameter, config config.Config) error {
+func (b CommandBuilder) validateArguments(context argumentValues, parameters []parser.Parameter, config config.Config) error {
 	err := errors.New("Invalid arguments:")
 	result := true
 	for _, parameter := range parameters {
@@ -250,17 +327,44 @@ func (b CommandBuilder) logger(debug bool, writer io.Writer) log.Logger {
 	return log.NewDefaultLogger(writer)
 }
 
-func (b CommandBuilder) outputWriter(writer io.Writer, format string, query string) output.OutputWriter {
+func (b CommandBuilder) outputWriter(writer io.Writer, settings outputSettings) output.OutputWriter {
+	if settings.SuccessOnly {
+		settings.SuccessOnly = false
+		return output.NewSuccessOutputWriter(b.outputWriter(writer, settings))
+	}
 	var transformer output.Transformer = output.NewDefaultTransformer()
-	if query != "" {
-		transformer = output.NewJmesPathTransformer(query)
+	if settings.Query != "" {
+		transformer = output.NewJmesPathTransformer(settings.Query)
 	}
-	if format == FlagValueOutputFormatText {
+	switch settings.Format {
+	case FlagValueOutputFormatText:
 		return output.NewTextOutputWriter(writer, transformer)
+	case FlagValueOutputFormatTable:
+		return output.NewTableOutputWriter(writer, transformer, settings.Columns, settings.Width)
+	case FlagValueOutputFormatCsv:
+		return output.NewCsvOutputWriter(writer, transformer, settings.Columns)
+	case FlagValueOutputFormatTsv:
+		return output.NewTsvOutputWriter(writer, transformer, settings.Columns)
+	case FlagValueOutputFormatYaml:
+		return output.NewYamlOutputWriter(writer, transformer)
+	case FlagValueOutputFormatTemplate:
+		return output.NewTemplateOutputWriter(writer, transformer, settings.Template)
+	case FlagValueOutputFormatNdJson:
+		return output.NewNdJsonOutputWriter(writer, transformer)
 	}
 	return output.NewJsonOutputWriter(writer, transformer)
 }
 
+func (b CommandBuilder) fileOutputWriter(outputFile string, settings outputSettings) output.OutputWriter {
+	if outputFile == "" {
+		return nil
+	}
+	settings.Width = 0
+	return output.NewFileOutputWriter(outputFile, func(writer io.Writer) output.OutputWriter {
+		return b.outputWriter(writer, settings)
+	})
+}
+
 func (b CommandBuilder) executeCommand(ctx executor.ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
 	if ctx.Plugin != nil {
 		return b.PluginExecutor.Call(ctx, writer, logger)
@@ -289,96 +393,412 @@ func (b CommandBuilder) createOperationCommand(operation parser.Operation) *Comm
 		WithHelpTemplate(OperationCommandHelpTemplate).
 		WithHidden(operation.Hidden).
 		WithAction(func(context *CommandExecContext) error {
-			profileName := context.String(FlagNameProfile)
-			config := b.ConfigProvider.Config(profileName)
-			if config == nil {
-				return fmt.Errorf("Could not find profile '%s'", profileName)
+			tracer := b.tracer(context)
+			err := b.executeOperation(context, operation, tracer)
+			if tracer != nil {
+				traceErr := b.writeTrace(tracer, context.String(FlagNameTraceFile), err)
+				if err == nil {
+					err = traceErr
+				}
 			}
-			outputFormat, err := b.outputFormat(*config, context)
-			if err != nil {
-				return err
+			if context.Bool(FlagNameNoFailOnHttpError) && b.isHttpError(err) {
+				if context.String(FlagNameOutputFile) != "" {
+					b.writeHttpErrorBody(err)
+				}
+				return nil
 			}
-			query := context.String(FlagNameQuery)
-			wait := context.String(FlagNameWait)
-			waitTimeout := context.Int(FlagNameWaitTimeout)
+			return err
+		})
+}
 
-			baseUri, err := b.createBaseUri(operation, *config, context)
-			if err != nil {
-				return err
-			}
+func (b CommandBuilder) isHttpError(err error) bool {
+	var httpError *network.HttpError
+	return errors.As(err, &httpError)
+}
 
-			input := b.fileInput(context, operation.Parameters)
-			if input == nil {
-				err = b.validateArguments(context, operation.Parameters, *config)
-				if err != nil {
-					return err
-				}
-			}
+// writeHttpErrorBody writes the error response body to standard error. The
+// file output writer does not write error responses to the output file and
+// the error itself is ignored when --no-fail-on-http-error is provided.
+func (b CommandBuilder) writeHttpErrorBody(err error) {
+	var httpError *network.HttpError
+	if !errors.As(err, &httpError) || len(httpError.Body) == 0 {
+		return
+	}
+	body := string(httpError.Body)
+	if !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+	_, _ = io.WriteString(b.StdErr, body)
+}
 
-			parameters, err := b.createExecutionParameters(context, config, operation)
-			if err != nil {
-				return err
-			}
+func (b CommandBuilder) tracer(context *CommandExecContext) *network.Tracer {
+	trace := context.Bool(FlagNameTrace)
+	if !trace && context.String(FlagNameTraceFile) == "" {
+		return nil
+	}
+	return network.NewTracer(context.FullName(), trace)
+}
 
-			organization := context.String(FlagNameOrganization)
-			if organization == "" {
-				organization = config.Organization
-			}
-			tenant := context.String(FlagNameTenant)
-			if tenant == "" {
-				tenant = config.Tenant
-			}
-			insecure := context.Bool(FlagNameInsecure) || config.Insecure
-			timeout := time.Duration(context.Int(FlagNameCallTimeout)) * time.Second
-			if timeout < 0 {
-				return fmt.Errorf("Invalid value for '%s'", FlagNameCallTimeout)
-			}
-			maxAttempts := context.Int(FlagNameMaxAttempts)
-			if maxAttempts < 1 {
-				return fmt.Errorf("Invalid value for '%s'", FlagNameMaxAttempts)
-			}
-			debug := context.Bool(FlagNameDebug) || config.Debug
-			identityUri, err := b.createIdentityUri(context, *config, baseUri)
-			if err != nil {
-				return err
+func (b CommandBuilder) writeTrace(tracer *network.Tracer, path string, err error) error {
+	tracer.Finish(err)
+	if path == "" {
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Error writing trace file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	return tracer.Export(file)
+}
+
+func (b CommandBuilder) executeOperation(context *CommandExecContext, operation parser.Operation, tracer *network.Tracer) error {
+	profileName := context.String(FlagNameProfile)
+	config := b.ConfigProvider.Config(profileName)
+	if config == nil {
+		return fmt.Errorf("Could not find profile '%s'", profileName)
+	}
+	err := b.validateErrorFormat(context)
+	if err != nil {
+		return NewValidationError(err)
+	}
+	outputSettings, err := b.outputSettings(*config, context)
+	if err != nil {
+		return NewValidationError(err)
+	}
+	wait := context.String(FlagNameWait)
+	waitTimeout := context.Int(FlagNameWaitTimeout)
+	outputFile := context.String(FlagNameOutputFile)
+	batch := context.String(FlagNameBatch)
+
+	if batch != "" {
+		return b.executeBatch(context, operation, *config, batch, *outputSettings, outputFile, tracer)
+	}
+
+	executionContext, err := b.createExecutionContext(context, context, operation, *config, tracer)
+	if err != nil {
+		return err
+	}
+	if wait != "" {
+		err = b.executeWait(*executionContext, *outputSettings, outputFile, wait, waitTimeout)
+	} else {
+		err = b.execute(*executionContext, *outputSettings, b.fileOutputWriter(outputFile, *outputSettings))
+	}
+	if err != nil {
+		return b.operationError(executionContext.Settings.OperationId, operation, err)
+	}
+	return nil
+}
+
+func (b CommandBuilder) operationError(requestId string, operation parser.Operation, err error) error {
+	var httpError *network.HttpError
+	if !errors.As(err, &httpError) {
+		return NewOperationError(requestId, "", "", err)
+	}
+	message := output.FormatServiceError(httpError.ServiceName(), httpError.StatusCode, httpError.Body)
+	hint := output.ServiceErrorHint(httpError.StatusCode, operation.Scopes)
+	return NewOperationError(requestId, message, hint, err)
+}
+
+func (b CommandBuilder) createExecutionContext(context *CommandExecContext, values argumentValues, operation parser.Operation, config config.Config, tracer *network.Tracer) (*executor.ExecutionContext, error) {
+	baseUri, err := b.createBaseUri(operation, config, context)
+	if err != nil {
+		return nil, err
+	}
+
+	input := b.fileInput(context, operation.Parameters)
+	if input == nil {
+		err = b.validateArguments(values, operation.Parameters, config)
+		if err != nil {
+			return nil, NewValidationError(err)
+		}
+	}
+
+	parameters, err := b.createExecutionParameters(values, &config, operation)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	organization := context.String(FlagNameOrganization)
+	if organization == "" {
+		organization = config.Organization
+	}
+	tenant := context.String(FlagNameTenant)
+	if tenant == "" {
+		tenant = config.Tenant
+	}
+	insecure := context.Bool(FlagNameInsecure) || config.Insecure
+	timeout := time.Duration(context.Int(FlagNameCallTimeout)) * time.Second
+	if timeout < 0 {
+		return nil, NewValidationError(fmt.Errorf("Invalid value for '%s'", FlagNameCallTimeout))
+	}
+	retryPolicy, err := b.retryPolicy(context, config)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+	retryNonIdempotent := context.Bool(FlagNameRetryNonIdempotent) || config.Retry.NonIdempotent
+	rateLimit, err := b.rateLimit(context, config)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+	transport, err := b.transportSettings(config)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+	connection := *network.NewConnectionSettings(config.Proxy, config.NoProxy, config.CaCertFile, config.ClientCertFile, config.ClientKeyFile, *transport)
+	debug := context.Bool(FlagNameDebug) || config.Debug
+	paginate := context.Bool(FlagNamePaginate)
+	dryRun := context.Bool(FlagNameDryRun)
+	dryRunAuth := context.Bool(FlagNameDryRunAuth)
+	exportFormat, err := b.exportFormat(context)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+	exportToken := context.Bool(FlagNameExportToken)
+	outputFile := context.String(FlagNameOutputFile)
+	identityUri, err := b.createIdentityUri(context, config, baseUri)
+	if err != nil {
+		return nil, err
+	}
+	operationId := b.operationId()
+
+	return executor.NewExecutionContext(
+		organization,
+		tenant,
+		operation.Method,
+		baseUri,
+		operation.Route,
+		operation.ContentType,
+		input,
+		parameters,
+		config.Auth,
+		*identityUri,
+		operation.Plugin,
+		operation.Pagination,
+		debug,
+		paginate,
+		dryRun,
+		dryRunAuth,
+		exportFormat,
+		exportToken,
+		*executor.NewExecutionSettings(operationId, config.Header, timeout, *retryPolicy, retryNonIdempotent, *rateLimit, connection, tracer, insecure, outputFile),
+	), nil
+}
+
+func (b CommandBuilder) retrySetting(context *CommandExecContext, name string, configValue string) string {
+	if !context.IsSet(name) && configValue != "" {
+		return configValue
+	}
+	return context.String(name)
+}
+
+func (b CommandBuilder) retryDuration(context *CommandExecContext, name string, configValue string) (time.Duration, error) {
+	duration, err := time.ParseDuration(b.retrySetting(context, name, configValue))
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("Invalid value for '%s'", name)
+	}
+	return duration, nil
+}
+
+func (b CommandBuilder) retryFloat(context *CommandExecContext, name string, configValue string, min float64) (float64, error) {
+	value, err := strconv.ParseFloat(b.retrySetting(context, name, configValue), 64)
+	if err != nil || value < min {
+		return 0, fmt.Errorf("Invalid value for '%s'", name)
+	}
+	return value, nil
+}
+
+func (b CommandBuilder) retryPolicy(context *CommandExecContext, config config.Config) (*resiliency.RetryPolicy, error) {
+	maxAttempts := context.Int(FlagNameMaxAttempts)
+	if !context.IsSet(FlagNameMaxAttempts) && config.Retry.MaxAttempts != 0 {
+		maxAttempts = config.Retry.MaxAttempts
+	}
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("Invalid value for '%s'", FlagNameMaxAttempts)
+	}
+	initialDelay, err := b.retryDuration(context, FlagNameRetryInitialDelay, config.Retry.InitialDelay)
+	if err != nil {
+		return nil, err
+	}
+	maxDelay, err := b.retryDuration(context, FlagNameRetryMaxDelay, config.Retry.MaxDelay)
+	if err != nil {
+		return nil, err
+	}
+	multiplier, err := b.retryFloat(context, FlagNameRetryMultiplier, config.Retry.Multiplier, 1)
+	if err != nil {
+		return nil, err
+	}
+	jitter, err := b.retryFloat(context, FlagNameRetryJitter, config.Retry.Jitter, 0)
+	if err != nil || jitter > 1 {
+		return nil, fmt.Errorf("Invalid value for '%s'", FlagNameRetryJitter)
+	}
+	return resiliency.NewRetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter), nil
+}
+
+func (b CommandBuilder) rateLimit(context *CommandExecContext, config config.Config) (*network.RateLimitSettings, error) {
+	requestsPerSecond := config.RateLimit.RequestsPerSecond
+	if context.String(FlagNameRateLimit) != "" {
+		value, err := strconv.ParseFloat(context.String(FlagNameRateLimit), 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("Invalid value for '%s'", FlagNameRateLimit)
+		}
+		requestsPerSecond = value
+	}
+	burst := config.RateLimit.Burst
+	if context.IsSet(FlagNameRateLimitBurst) {
+		burst = context.Int(FlagNameRateLimitBurst)
+	}
+	if burst < 0 {
+		return nil, fmt.Errorf("Invalid value for '%s'", FlagNameRateLimitBurst)
+	}
+	hosts := map[string]network.RateLimit{}
+	for host, limit := range config.RateLimit.Hosts {
+		hosts[host] = *network.NewRateLimit(limit.RequestsPerSecond, limit.Burst)
+	}
+	return network.NewRateLimitSettings(*network.NewRateLimit(requestsPerSecond, burst), hosts), nil
+}
+
+func (b CommandBuilder) transportSettings(config config.Config) (*network.TransportSettings, error) {
+	pool := config.ConnectionPool
+	if pool.MaxIdle < 0 {
+		return nil, errors.New("Invalid value for 'connectionPool.maxIdle'")
+	}
+	if pool.MaxIdlePerHost < 0 {
+		return nil, errors.New("Invalid value for 'connectionPool.maxIdlePerHost'")
+	}
+	idleTimeout := time.Duration(0)
+	if pool.IdleTimeout != "" {
+		var err error
+		idleTimeout, err = time.ParseDuration(pool.IdleTimeout)
+		if err != nil || idleTimeout < 0 {
+			return nil, errors.New("Invalid value for 'connectionPool.idleTimeout'")
+		}
+	}
+	return network.NewTransportSettings(pool.MaxIdle, pool.MaxIdlePerHost, idleTimeout), nil
+}
+
+func (b CommandBuilder) batchInput(batch string) stream.Stream {
+	if batch == FlagValueFromStdIn {
+		return b.Input
+	}
+	return stream.NewFileStream(batch)
+}
+
+func (b CommandBuilder) validateBatchRow(row batchRow, parameters []parser.Parameter) error {
+	for _, name := range row.Names() {
+		found := false
+		for _, parameter := range parameters {
+			if parameter.Name == name {
+				found = true
+				break
 			}
-			operationId := b.operationId()
-
-			executionContext := executor.NewExecutionContext(
-				organization,
-				tenant,
-				operation.Method,
-				baseUri,
-				operation.Route,
-				operation.ContentType,
-				input,
-				parameters,
-				config.Auth,
-				*identityUri,
-				operation.Plugin,
-				debug,
-				*executor.NewExecutionSettings(operationId, config.Header, timeout, maxAttempts, insecure),
-			)
-
-			if wait != "" {
-				return b.executeWait(*executionContext, outputFormat, query, wait, waitTimeout)
+		}
+		if !found {
+			return fmt.Errorf("Unknown argument '%s'", name)
+		}
+	}
+	return nil
+}
+
+func (b CommandBuilder) executeBatchRow(context *CommandExecContext, operation parser.Operation, config config.Config, row batchRow, tracer *network.Tracer) batchRowResult {
+	err := b.validateBatchRow(row, operation.Parameters)
+	if err != nil {
+		return *newBatchRowError(row.Number, err)
+	}
+	executionContext, err := b.createExecutionContext(context, row, operation, config, tracer)
+	if err != nil {
+		return *newBatchRowError(row.Number, err)
+	}
+	outputWriter := output.NewMemoryOutputWriter()
+	logger := b.logger(executionContext.Debug, b.StdErr)
+	err = b.executeCommand(*executionContext, outputWriter, logger)
+	if err != nil && !b.isHttpError(err) {
+		return *newBatchRowError(row.Number, err)
+	}
+	return *newBatchRowResult(row.Number, outputWriter.Response())
+}
+
+func (b CommandBuilder) executeBatch(context *CommandExecContext, operation parser.Operation, config config.Config, batch string, outputSettings outputSettings, outputFile string, tracer *network.Tracer) error {
+	concurrency := context.Int(FlagNameBatchConcurrency)
+	if concurrency < 1 {
+		return NewValidationError(fmt.Errorf("Invalid value for '%s'", FlagNameBatchConcurrency))
+	}
+	input := b.batchInput(batch)
+	if input == nil {
+		return errors.New("No batch input provided on standard input")
+	}
+	rows, err := newBatchReader().Read(input, context)
+	if err != nil {
+		return err
+	}
+
+	writer := b.fileOutputWriter(outputFile, outputSettings)
+	if writer == nil {
+		writer = b.outputWriter(b.StdOut, outputSettings)
+	}
+	streaming := output.IsStreaming(writer)
+
+	results := make([]batchRowResult, len(rows))
+	errs := make([]error, len(rows))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, row := range rows {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = b.executeBatchRow(context, operation, config, row, tracer)
+			if streaming {
+				errs[i] = b.writeBatchResult(writer, results[i])
 			}
-			return b.execute(*executionContext, outputFormat, query, nil)
-		})
+		}()
+	}
+	wg.Wait()
+
+	result := newBatchResult(results)
+	if streaming {
+		err = errors.Join(errs...)
+	} else {
+		err = b.writeBatchResult(writer, result)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("Batch execution failed for %d of %d rows", result.Failed, result.Total)
+	}
+	return nil
 }
 
-func (b CommandBuilder) executeWait(ctx executor.ExecutionContext, outputFormat string, query string, wait string, waitTimeout int) error {
+// writeBatchResult writes the summary of all rows or, in case the output
+// writer supports streaming, the result of a single row once it completed.
+func (b CommandBuilder) writeBatchResult(writer output.OutputWriter, result interface{}) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("Error writing batch result: %w", err)
+	}
+	return writer.WriteResponse(*output.NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewReader(body)))
+}
+
+func (b CommandBuilder) executeWait(ctx executor.ExecutionContext, outputSettings outputSettings, outputFile string, wait string, waitTimeout int) error {
 	logger := log.NewDefaultLogger(b.StdErr)
 	outputWriter := output.NewMemoryOutputWriter()
 	for start := time.Now(); time.Since(start) < time.Duration(waitTimeout)*time.Second; {
-		err := b.execute(ctx, "json", "", outputWriter)
+		err := b.execute(ctx, *newOutputSettings(FlagValueOutputFormatJson, "", []string{}, 0, nil, false), outputWriter)
 		result, evaluationErr := b.evaluateWaitCondition(outputWriter.Response(), wait)
 		if evaluationErr != nil {
 			return evaluationErr
 		}
 		if result {
-			resultWriter := b.outputWriter(b.StdOut, outputFormat, query)
-			_ = resultWriter.WriteResponse(outputWriter.Response())
+			resultWriter := b.fileOutputWriter(outputFile, outputSettings)
+			if resultWriter == nil {
+				resultWriter = b.outputWriter(b.StdOut, outputSettings)
+			}
+			writeErr := resultWriter.WriteResponse(outputWriter.Response())
+			if err == nil {
+				err = writeErr
+			}
 			return err
 		}
 		logger.LogError("Condition is not met yet. Waiting...\n")
@@ -409,7 +829,7 @@ func (b CommandBuilder) evaluateWaitCondition(response output.ResponseInfo, wait
 	return value, nil
 }
 
-func (b CommandBuilder) execute(ctx executor.ExecutionContext, outputFormat string, query string, outputWriter output.OutputWriter) error {
+func (b CommandBuilder) execute(ctx executor.ExecutionContext, outputSettings outputSettings, outputWriter output.OutputWriter) error {
 	var wg sync.WaitGroup
 	wg.Add(3)
 	reader, writer := io.Pipe()
@@ -431,7 +851,7 @@ func (b CommandBuilder) execute(ctx executor.ExecutionContext, outputFormat stri
 		defer func() { _ = writer.Close() }()
 		defer func() { _ = errorWriter.Close() }()
 		if outputWriter == nil {
-			outputWriter = b.outputWriter(writer, outputFormat, query)
+			outputWriter = b.outputWriter(writer, outputSettings)
 		}
 		logger := b.logger(ctx.Debug, errorWriter)
 		err = b.executeCommand(ctx, outputWriter, logger)
@@ -32,6 +32,11 @@ const ConfigKeyOrganization = "organization"
 const ConfigKeyTenant = "tenant"
 const ConfigKeyUri = "uri"
 const ConfigKeyInsecure = "insecure"
+const ConfigKeyProxy = "proxy"
+const ConfigKeyNoProxy = "noProxy"
+const ConfigKeyCaCertFile = "caCertFile"
+const ConfigKeyClientCertFile = "clientCertFile"
+const ConfigKeyClientKeyFile = "clientKeyFile"
 const ConfigKeyDebug = "debug"
 const ConfigKeyAuthGrantType = "auth.grantType"
 const ConfigKeyAuthScopes = "auth.scopes"
@@ -46,6 +51,11 @@ var ConfigKeys = []string{
 	ConfigKeyTenant,
 	ConfigKeyUri,
 	ConfigKeyInsecure,
+	ConfigKeyProxy,
+	ConfigKeyNoProxy,
+	ConfigKeyCaCertFile,
+	ConfigKeyClientCertFile,
+	ConfigKeyClientKeyFile,
 	ConfigKeyDebug,
 	ConfigKeyAuthGrantType,
 	ConfigKeyAuthScopes,
@@ -89,6 +99,20 @@ func (h configCommandHandler) setConfigValue(cfg *config.Config, key string, val
 		}
 		cfg.SetInsecure(insecure)
 		return nil
+	} else if key == ConfigKeyProxy {
+		return cfg.SetProxy(value)
+	} else if key == ConfigKeyNoProxy {
+		cfg.SetNoProxy(value)
+		return nil
+	} else if key == ConfigKeyCaCertFile {
+		cfg.SetCaCertFile(value)
+		return nil
+	} else if key == ConfigKeyClientCertFile {
+		cfg.SetClientCertFile(value)
+		return nil
+	} else if key == ConfigKeyClientKeyFile {
+		cfg.SetClientKeyFile(value)
+		return nil
 	} else if key == ConfigKeyDebug {
 		debug, err := h.convertToBool(value)
 		if err != nil {
@@ -125,9 +125,10 @@ func (p DefinitionProvider) applyPluginCommand(plugin plugin.CommandPlugin, comm
 		category = parser.NewOperationCategory(command.Category.Name, command.Category.Summary, command.Category.Description)
 	}
 	baseUri, _ := url.Parse(parser.DefaultServerBaseUrl)
-	operation := parser.NewOperation(command.Name, command.Description, "", "", *baseUri, "", "application/json", parameters, plugin, command.Hidden, category)
+	operation := parser.NewOperation(command.Name, command.Description, "", "", *baseUri, "", "application/json", parameters, plugin, command.Hidden, category, nil, nil)
 	for i := range definition.Operations {
 		if definition.Operations[i].Name == command.Name {
+			operation.Scopes = definition.Operations[i].Scopes
 			definition.Operations[i] = *operation
 			return
 		}
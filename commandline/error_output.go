@@ -0,0 +1,77 @@
+package commandline
+
+import (
+	"errors"
+
+	"github.com/UiPath/uipathcli/output"
+	"github.com/UiPath/uipathcli/utils/network"
+)
+
+// The errorOutput is the structured representation of an error which is
+// written to standard error when --error-format json is provided.
+//
+// Example:
+//
+//	{
+//	  "category": "authenticationError",
+//	  "exitCode": 3,
+//	  "message": "Service returned status code '403': You are not authorized! (error code: 0, trace id: 00-abc-def-00)",
+//	  "hint": "Hint: Make sure your credentials have been granted the OAuth scopes for this operation: one of: OR.Users, OR.Users.Read",
+//	  "status": 403,
+//	  "requestId": "b9bb1fbc8fe04dd3a1e8a5b0f0d9b4e6",
+//	  "service": {
+//	    "errorCode": 0,
+//	    "message": "You are not authorized!",
+//	    "traceId": "00-abc-def-00"
+//	  }
+//	}
+type errorOutput struct {
+	Category  string               `json:"category"`
+	ExitCode  int                  `json:"exitCode"`
+	Message   string               `json:"message"`
+	Hint      string               `json:"hint,omitempty"`
+	Status    int                  `json:"status,omitempty"`
+	RequestId string               `json:"requestId,omitempty"`
+	Service   *output.ServiceError `json:"service,omitempty"`
+}
+
+func errorCategory(exitCode int) string {
+	switch exitCode {
+	case ExitCodeValidationError:
+		return "validationError"
+	case ExitCodeAuthenticationError:
+		return "authenticationError"
+	case ExitCodeNotFound:
+		return "notFound"
+	case ExitCodeConflict:
+		return "conflict"
+	case ExitCodeClientError:
+		return "clientError"
+	case ExitCodeServerError:
+		return "serverError"
+	case ExitCodeNetworkError:
+		return "networkError"
+	}
+	return "error"
+}
+
+func newErrorOutput(err error) *errorOutput {
+	exitCode := ExitCode(err)
+	result := errorOutput{
+		Category: errorCategory(exitCode),
+		ExitCode: exitCode,
+		Message:  err.Error(),
+	}
+	var operationError *OperationError
+	if errors.As(err, &operationError) {
+		result.RequestId = operationError.RequestId
+		result.Message = operationError.message()
+		result.Hint = operationError.Hint
+	}
+	var httpError *network.HttpError
+	if errors.As(err, &httpError) {
+		result.Status = httpError.StatusCode
+		result.Service = output.ParseServiceError(httpError.Body)
+	}
+	return &result
+}
@@ -0,0 +1,67 @@
+package commandline
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/UiPath/uipathcli/auth"
+	"github.com/UiPath/uipathcli/executor"
+	"github.com/UiPath/uipathcli/utils/network"
+)
+
+// The process exit codes returned by the CLI.
+const (
+	ExitCodeSuccess             = 0
+	ExitCodeError               = 1
+	ExitCodeValidationError     = 2
+	ExitCodeAuthenticationError = 3
+	ExitCodeNotFound            = 4
+	ExitCodeConflict            = 5
+	ExitCodeClientError         = 6
+	ExitCodeServerError         = 7
+	ExitCodeNetworkError        = 8
+)
+
+// ExitCode maps the error returned by the CLI to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	var validationError *ValidationError
+	if errors.As(err, &validationError) {
+		return ExitCodeValidationError
+	}
+	var executorValidationError *executor.ValidationError
+	if errors.As(err, &executorValidationError) {
+		return ExitCodeValidationError
+	}
+	var authenticationError *auth.AuthenticationError
+	if errors.As(err, &authenticationError) {
+		return ExitCodeAuthenticationError
+	}
+	var httpError *network.HttpError
+	if errors.As(err, &httpError) {
+		return httpExitCode(httpError.StatusCode)
+	}
+	var networkError *network.NetworkError
+	if errors.As(err, &networkError) {
+		return ExitCodeNetworkError
+	}
+	return ExitCodeError
+}
+
+func httpExitCode(statusCode int) int {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ExitCodeAuthenticationError
+	case statusCode == http.StatusNotFound:
+		return ExitCodeNotFound
+	case statusCode == http.StatusConflict:
+		return ExitCodeConflict
+	case statusCode >= 400 && statusCode < 500:
+		return ExitCodeClientError
+	case statusCode >= 500:
+		return ExitCodeServerError
+	}
+	return ExitCodeError
+}
@@ -2,20 +2,39 @@ package commandline
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/UiPath/uipathcli/config"
+	"github.com/UiPath/uipathcli/executor"
+	"github.com/UiPath/uipathcli/utils/resiliency"
 )
 
 const FlagNameDebug = "debug"
+const FlagNameTrace = "trace"
+const FlagNameTraceFile = "trace-file"
 const FlagNameProfile = "profile"
 const FlagNameUri = "uri"
 const FlagNameOrganization = "organization"
 const FlagNameTenant = "tenant"
 const FlagNameInsecure = "insecure"
 const FlagNameOutputFormat = "output"
+const FlagNameOutputFile = "output-file"
+const FlagNameErrorFormat = "error-format"
 const FlagNameQuery = "query"
+const FlagNameColumns = "columns"
+const FlagNameTemplate = "template"
+const FlagNameTemplateFile = "template-file"
 const FlagNameWait = "wait"
 const FlagNameWaitTimeout = "wait-timeout"
+const FlagNamePaginate = "paginate"
+const FlagNameBatch = "batch"
+const FlagNameBatchConcurrency = "batch-concurrency"
+const FlagNameDryRun = "dry-run"
+const FlagNameDryRunAuth = "dry-run-auth"
+const FlagNameExport = "export"
+const FlagNameExportToken = "export-token"
+const FlagNameNoFailOnHttpError = "no-fail-on-http-error"
 const FlagNameFile = "file"
 const FlagNameIdentityUri = "identity-uri"
 const FlagNameServiceVersion = "service-version"
@@ -23,13 +42,48 @@ const FlagNameHelp = "help"
 const FlagNameVersion = "version"
 const FlagNameCallTimeout = "call-timeout"
 const FlagNameMaxAttempts = "max-attempts"
+const FlagNameRetryInitialDelay = "retry-initial-delay"
+const FlagNameRetryMaxDelay = "retry-max-delay"
+const FlagNameRetryMultiplier = "retry-multiplier"
+const FlagNameRetryJitter = "retry-jitter"
+const FlagNameRetryNonIdempotent = "retry-non-idempotent"
+const FlagNameRateLimit = "rate-limit"
+const FlagNameRateLimitBurst = "rate-limit-burst"
 
 const FlagValueFromStdIn = "-"
+const FlagValueFileReference = "@"
+const FlagValueEscapedFileReference = "@@"
 const FlagValueOutputFormatJson = "json"
+const FlagValueOutputFormatNdJson = "ndjson"
 const FlagValueOutputFormatText = "text"
+const FlagValueOutputFormatTable = "table"
+const FlagValueOutputFormatCsv = "csv"
+const FlagValueOutputFormatTsv = "tsv"
+const FlagValueOutputFormatYaml = "yaml"
+const FlagValueOutputFormatTemplate = "template"
+const FlagValueErrorFormatText = "text"
+const FlagValueErrorFormatJson = "json"
+
+var FlagValuesOutputFormat = []string{
+	FlagValueOutputFormatJson,
+	FlagValueOutputFormatText,
+	FlagValueOutputFormatTable,
+	FlagValueOutputFormatCsv,
+	FlagValueOutputFormatTsv,
+	FlagValueOutputFormatYaml,
+	FlagValueOutputFormatTemplate,
+	FlagValueOutputFormatNdJson,
+}
+
+var FlagValuesErrorFormat = []string{
+	FlagValueErrorFormatText,
+	FlagValueErrorFormatJson,
+}
 
 var FlagNamesPredefined = []string{
 	FlagNameDebug,
+	FlagNameTrace,
+	FlagNameTraceFile,
 	FlagNameProfile,
 	FlagNameUri,
 	FlagNameOrganization,
@@ -37,10 +91,30 @@ var FlagNamesPredefined = []string{
 	FlagNameInsecure,
 	FlagNameCallTimeout,
 	FlagNameMaxAttempts,
+	FlagNameRetryInitialDelay,
+	FlagNameRetryMaxDelay,
+	FlagNameRetryMultiplier,
+	FlagNameRetryJitter,
+	FlagNameRetryNonIdempotent,
+	FlagNameRateLimit,
+	FlagNameRateLimitBurst,
 	FlagNameOutputFormat,
+	FlagNameOutputFile,
+	FlagNameErrorFormat,
 	FlagNameQuery,
+	FlagNameColumns,
+	FlagNameTemplate,
+	FlagNameTemplateFile,
 	FlagNameWait,
 	FlagNameWaitTimeout,
+	FlagNamePaginate,
+	FlagNameBatch,
+	FlagNameBatchConcurrency,
+	FlagNameDryRun,
+	FlagNameDryRunAuth,
+	FlagNameExport,
+	FlagNameExportToken,
+	FlagNameNoFailOnHttpError,
 	FlagNameFile,
 	FlagNameIdentityUri,
 	FlagNameServiceVersion,
@@ -105,6 +179,14 @@ func (b *FlagBuilder) defaultFlags(hidden bool) []*FlagDefinition {
 			WithEnvVarName("UIPATH_DEBUG").
 			WithDefaultValue(false).
 			WithHidden(hidden),
+		NewFlag(FlagNameTrace, "Print the timings of every request", FlagTypeBoolean).
+			WithEnvVarName("UIPATH_TRACE").
+			WithDefaultValue(false).
+			WithHidden(hidden),
+		NewFlag(FlagNameTraceFile, "Write the request spans to an OpenTelemetry JSON file", FlagTypeString).
+			WithEnvVarName("UIPATH_TRACE_FILE").
+			WithDefaultValue("").
+			WithHidden(hidden),
 		NewFlag(FlagNameProfile, "Config profile to use", FlagTypeString).
 			WithEnvVarName("UIPATH_PROFILE").
 			WithDefaultValue(config.DefaultProfile).
@@ -130,19 +212,91 @@ func (b *FlagBuilder) defaultFlags(hidden bool) []*FlagDefinition {
 			WithEnvVarName("UIPATH_MAX_ATTEMPTS").
 			WithDefaultValue(3).
 			WithHidden(true),
-		NewFlag(FlagNameOutputFormat, fmt.Sprintf("Set output format: %s (default), %s", FlagValueOutputFormatJson, FlagValueOutputFormatText), FlagTypeString).
+		NewFlag(FlagNameRetryInitialDelay, "Delay before the first retry", FlagTypeString).
+			WithEnvVarName("UIPATH_RETRY_INITIAL_DELAY").
+			WithDefaultValue(resiliency.DefaultInitialDelay.String()).
+			WithHidden(true),
+		NewFlag(FlagNameRetryMaxDelay, "Maximum delay between retries", FlagTypeString).
+			WithEnvVarName("UIPATH_RETRY_MAX_DELAY").
+			WithDefaultValue(resiliency.DefaultMaxDelay.String()).
+			WithHidden(true),
+		NewFlag(FlagNameRetryMultiplier, "Factor to increase the delay after every retry", FlagTypeString).
+			WithEnvVarName("UIPATH_RETRY_MULTIPLIER").
+			WithDefaultValue(strconv.FormatFloat(resiliency.DefaultMultiplier, 'f', -1, 64)).
+			WithHidden(true),
+		NewFlag(FlagNameRetryJitter, "Fraction to randomize the delay between retries", FlagTypeString).
+			WithEnvVarName("UIPATH_RETRY_JITTER").
+			WithDefaultValue(strconv.FormatFloat(resiliency.DefaultJitter, 'f', -1, 64)).
+			WithHidden(true),
+		NewFlag(FlagNameRetryNonIdempotent, "Retry non-idempotent requests like POST and PATCH", FlagTypeBoolean).
+			WithEnvVarName("UIPATH_RETRY_NON_IDEMPOTENT").
+			WithDefaultValue(false).
+			WithHidden(true),
+		NewFlag(FlagNameRateLimit, "Maximum number of requests per second sent to a service host", FlagTypeString).
+			WithEnvVarName("UIPATH_RATE_LIMIT").
+			WithDefaultValue("").
+			WithHidden(true),
+		NewFlag(FlagNameRateLimitBurst, "Number of requests which can be sent at once before the rate limit applies", FlagTypeInteger).
+			WithEnvVarName("UIPATH_RATE_LIMIT_BURST").
+			WithDefaultValue(0).
+			WithHidden(true),
+		NewFlag(FlagNameOutputFormat, fmt.Sprintf("Set output format: %s (default), %s", FlagValueOutputFormatJson, strings.Join(FlagValuesOutputFormat[1:], ", ")), FlagTypeString).
 			WithEnvVarName("UIPATH_OUTPUT").
 			WithDefaultValue("").
 			WithHidden(hidden),
+		NewFlag(FlagNameOutputFile, "Write output to file instead of standard output", FlagTypeString).
+			WithDefaultValue("").
+			WithHidden(hidden),
+		NewFlag(FlagNameErrorFormat, fmt.Sprintf("Set error format: %s (default), %s", FlagValueErrorFormatText, strings.Join(FlagValuesErrorFormat[1:], ", ")), FlagTypeString).
+			WithEnvVarName("UIPATH_ERROR_FORMAT").
+			WithDefaultValue("").
+			WithHidden(hidden),
 		NewFlag(FlagNameQuery, "Perform JMESPath query on output", FlagTypeString).
 			WithDefaultValue("").
 			WithHidden(hidden),
+		NewFlag(FlagNameColumns, "Comma-separated list of columns shown in the table, csv and tsv output", FlagTypeString).
+			WithDefaultValue("").
+			WithHidden(hidden),
+		NewFlag(FlagNameTemplate, "Go template used to format the template output", FlagTypeString).
+			WithDefaultValue("").
+			WithHidden(hidden),
+		NewFlag(FlagNameTemplateFile, "File containing the go template used to format the template output", FlagTypeString).
+			WithDefaultValue("").
+			WithHidden(hidden),
 		NewFlag(FlagNameWait, "Waits for the provided condition (JMESPath expression)", FlagTypeString).
 			WithDefaultValue("").
 			WithHidden(hidden),
 		NewFlag(FlagNameWaitTimeout, "Time to wait in seconds for condition", FlagTypeInteger).
 			WithDefaultValue(30).
 			WithHidden(hidden),
+		NewFlag(FlagNamePaginate, "Retrieve all pages of the result", FlagTypeBoolean).
+			WithEnvVarName("UIPATH_PAGINATE").
+			WithDefaultValue(false).
+			WithHidden(hidden),
+		NewFlag(FlagNameBatch, "Execute the operation for every row of the JSONL or CSV file (use - for stdin)", FlagTypeString).
+			WithDefaultValue("").
+			WithHidden(hidden),
+		NewFlag(FlagNameBatchConcurrency, "Number of rows executed in parallel", FlagTypeInteger).
+			WithEnvVarName("UIPATH_BATCH_CONCURRENCY").
+			WithDefaultValue(1).
+			WithHidden(hidden),
+		NewFlag(FlagNameDryRun, "Print the request instead of sending it", FlagTypeBoolean).
+			WithEnvVarName("UIPATH_DRY_RUN").
+			WithDefaultValue(false).
+			WithHidden(hidden),
+		NewFlag(FlagNameDryRunAuth, "Authenticate during dry run to include the redacted Authorization header", FlagTypeBoolean).
+			WithDefaultValue(false).
+			WithHidden(hidden),
+		NewFlag(FlagNameExport, fmt.Sprintf("Print the request as command instead of sending it: %s", strings.Join(executor.ExportFormats, ", ")), FlagTypeString).
+			WithDefaultValue("").
+			WithHidden(hidden),
+		NewFlag(FlagNameExportToken, "Include a bearer token in the exported command", FlagTypeBoolean).
+			WithDefaultValue(false).
+			WithHidden(hidden),
+		NewFlag(FlagNameNoFailOnHttpError, "Exit with success code when the service returns an error status code", FlagTypeBoolean).
+			WithEnvVarName("UIPATH_NO_FAIL_ON_HTTP_ERROR").
+			WithDefaultValue(false).
+			WithHidden(hidden),
 		NewFlag(FlagNameFile, "Provide input from file (use - for stdin)", FlagTypeString).
 			WithDefaultValue("").
 			WithHidden(hidden),
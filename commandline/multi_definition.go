@@ -27,7 +27,9 @@ func (d multiDefinition) Merge(name string, definitions []*parser.Definition) *p
 				operation.Parameters,
 				operation.Plugin,
 				operation.Hidden,
-				category))
+				category,
+				operation.Pagination,
+				operation.Scopes))
 		}
 	}
 	return parser.NewDefinition(name, definitions[0].Summary, definitions[0].Description, operations)
@@ -0,0 +1,36 @@
+package commandline
+
+// The OperationError is returned when the execution of an operation failed.
+// It keeps the request id which was sent to the service in the x-request-id
+// header so that the failure can be correlated with the service logs.
+//
+// Service errors are rendered as a concise message and, for authorization
+// failures, an actionable hint is shown.
+type OperationError struct {
+	RequestId string
+	Message   string
+	Hint      string
+	err       error
+}
+
+func (e OperationError) message() string {
+	if e.Message == "" {
+		return e.err.Error()
+	}
+	return e.Message
+}
+
+func (e OperationError) Error() string {
+	if e.Hint == "" {
+		return e.message()
+	}
+	return e.message() + "\n" + e.Hint
+}
+
+func (e OperationError) Unwrap() error {
+	return e.err
+}
+
+func NewOperationError(requestId string, message string, hint string, err error) *OperationError {
+	return &OperationError{requestId, message, hint, err}
+}
@@ -0,0 +1,21 @@
+package commandline
+
+import "text/template"
+
+// The outputSettings contain the arguments which control how the
+// response is formatted before it is written to the output.
+//
+// SuccessOnly skips writing error responses which are reported as
+// structured errors instead.
+type outputSettings struct {
+	Format      string
+	Query       string
+	Columns     []string
+	Width       int
+	Template    *template.Template
+	SuccessOnly bool
+}
+
+func newOutputSettings(format string, query string, columns []string, width int, template *template.Template, successOnly bool) *outputSettings {
+	return &outputSettings{format, query, columns, width, template, successOnly}
+}
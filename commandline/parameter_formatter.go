@@ -132,6 +132,8 @@ func (f parameterFormatter) humanReadableType(_type string) string {
 		return "boolean,boolean,..."
 	case parser.ParameterTypeObjectArray:
 		return "object (multiple)"
+	case parser.ParameterTypeBinaryArray:
+		return "binary (multiple)"
 	default:
 		return "object"
 	}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package commandline
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalColumns returns the number of columns of the terminal on linux and
+// macOS or 0 in case the file is not a terminal.
+func terminalColumns(file *os.File) int {
+	size, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ) //nolint:gosec // file descriptors fit into int
+	if err != nil {
+		return 0
+	}
+	return int(size.Col)
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package commandline
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// terminalColumns returns the number of columns of the console window on windows
+// or 0 in case the file is not a console.
+func terminalColumns(file *os.File) int {
+	var info windows.ConsoleScreenBufferInfo
+	err := windows.GetConsoleScreenBufferInfo(windows.Handle(file.Fd()), &info)
+	if err != nil {
+		return 0
+	}
+	return int(info.Window.Right - info.Window.Left + 1)
+}
@@ -3,6 +3,7 @@ package commandline
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
@@ -13,7 +14,13 @@ import (
 // The typeConverter converts the string value from the command-line argument into the type
 // the definition declared. CLI arguments are always passed as strings and need to be converted
 // to their respective type.
-type typeConverter struct{}
+//
+// String, string array and object values prefixed with an at-sign are read
+// from a file, e.g. @path/to/file.json, or from standard input using @-. A
+// double at-sign (@@) passes a literal value starting with an at-sign.
+type typeConverter struct {
+	input stream.Stream
+}
 
 func (c typeConverter) trimAll(values []string) []string {
 	result := []string{}
@@ -282,7 +289,52 @@ func (c typeConverter) splitEscapedMaxCount(str string, separator byte, maxCount
 	return result
 }
 
+func (c typeConverter) readFileReference(value string) (string, error) {
+	if strings.HasPrefix(value, FlagValueEscapedFileReference) {
+		return value[1:], nil
+	}
+	if !strings.HasPrefix(value, FlagValueFileReference) {
+		return value, nil
+	}
+	path := strings.TrimPrefix(value, FlagValueFileReference)
+	if path == "" {
+		return "", fmt.Errorf("Missing file path in value '%s'", value)
+	}
+
+	var input stream.Stream = stream.NewFileStream(path)
+	if path == FlagValueFromStdIn {
+		if c.input == nil {
+			return "", fmt.Errorf("No input provided on standard input")
+		}
+		input = c.input
+	}
+	reader, err := input.Data()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("Error reading file '%s': %w", path, err)
+	}
+	return string(data), nil
+}
+
+func (c typeConverter) isFileReferenceSupported(parameter parser.Parameter) bool {
+	return parameter.Type == parser.ParameterTypeString ||
+		parameter.Type == parser.ParameterTypeStringArray ||
+		parameter.Type == parser.ParameterTypeObject ||
+		parameter.Type == parser.ParameterTypeObjectArray
+}
+
 func (c typeConverter) Convert(value string, parameter parser.Parameter) (interface{}, error) {
+	if c.isFileReferenceSupported(parameter) {
+		var err error
+		value, err = c.readFileReference(value)
+		if err != nil {
+			return nil, err
+		}
+	}
 	switch parameter.Type {
 	case parser.ParameterTypeInteger:
 		return c.convertToInteger(value, parameter)
@@ -304,6 +356,8 @@ func (c typeConverter) Convert(value string, parameter parser.Parameter) (interf
 		return c.convertValueToBooleanArray(value, parameter)
 	case parser.ParameterTypeObjectArray:
 		return c.convertToObject(value, parameter)
+	case parser.ParameterTypeBinaryArray:
+		return c.convertToBinaryArray([]string{value})
 	default:
 		return value, nil
 	}
@@ -312,6 +366,10 @@ func (c typeConverter) Convert(value string, parameter parser.Parameter) (interf
 func (c typeConverter) convertToObjectArray(values []string, parameter parser.Parameter) ([]interface{}, error) {
 	result := []interface{}{}
 	for _, value := range values {
+		value, err := c.readFileReference(value)
+		if err != nil {
+			return nil, err
+		}
 		item, err := c.convertToObject(value, parameter)
 		if err != nil {
 			return nil, err
@@ -321,9 +379,25 @@ func (c typeConverter) convertToObjectArray(values []string, parameter parser.Pa
 	return result, nil
 }
 
+func (c typeConverter) convertToBinaryArray(values []string) ([]stream.Stream, error) {
+	result := []stream.Stream{}
+	for _, value := range values {
+		if value == FlagValueFromStdIn && c.input != nil {
+			result = append(result, c.input)
+			continue
+		}
+		result = append(result, stream.NewFileStream(value))
+	}
+	return result, nil
+}
+
 func (c typeConverter) convertToStringArray(values []string) ([]string, error) {
 	result := []string{}
 	for _, value := range values {
+		value, err := c.readFileReference(value)
+		if err != nil {
+			return nil, err
+		}
 		items, err := c.convertValueToStringArray(value)
 		if err != nil {
 			return nil, err
@@ -373,6 +447,8 @@ func (c typeConverter) ConvertArray(values []string, parameter parser.Parameter)
 	switch parameter.Type {
 	case parser.ParameterTypeObjectArray:
 		return c.convertToObjectArray(values, parameter)
+	case parser.ParameterTypeBinaryArray:
+		return c.convertToBinaryArray(values)
 	case parser.ParameterTypeStringArray:
 		return c.convertToStringArray(values)
 	case parser.ParameterTypeIntegerArray:
@@ -386,6 +462,6 @@ func (c typeConverter) ConvertArray(values []string, parameter parser.Parameter)
 	}
 }
 
-func newTypeConverter() *typeConverter {
-	return &typeConverter{}
+func newTypeConverter(input stream.Stream) *typeConverter {
+	return &typeConverter{input}
 }
@@ -1,6 +1,8 @@
 package commandline
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/UiPath/uipathcli/parser"
@@ -8,7 +10,7 @@ import (
 )
 
 func TestConvertReturnsErrorForInvalidBoolean(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("enabled", parser.ParameterTypeBoolean, []parser.Parameter{})
 	_, err := converter.Convert("invalid", parameter)
@@ -19,7 +21,7 @@ func TestConvertReturnsErrorForInvalidBoolean(t *testing.T) {
 }
 
 func TestConvertStringToBoolean(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("enabled", parser.ParameterTypeBoolean, []parser.Parameter{})
 	result, err := converter.Convert("true", parameter)
@@ -33,7 +35,7 @@ func TestConvertStringToBoolean(t *testing.T) {
 }
 
 func TestConvertStringToFileStream(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("file", parser.ParameterTypeBinary, []parser.Parameter{})
 	result, err := converter.Convert("test.txt", parameter)
@@ -48,7 +50,7 @@ func TestConvertStringToFileStream(t *testing.T) {
 }
 
 func TestConvertCommaSeparatedStringToIntegerArray(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("metrics", parser.ParameterTypeIntegerArray, []parser.Parameter{})
 	result, err := converter.Convert("5,2", parameter)
@@ -63,7 +65,7 @@ func TestConvertCommaSeparatedStringToIntegerArray(t *testing.T) {
 }
 
 func TestConvertStringToIntegerArray(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("metrics", parser.ParameterTypeIntegerArray, []parser.Parameter{})
 	result, err := converter.ConvertArray([]string{"5,2", "3"}, parameter)
@@ -78,7 +80,7 @@ func TestConvertStringToIntegerArray(t *testing.T) {
 }
 
 func TestConvertExpressionToObject(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("tag", parser.ParameterTypeObject,
 		[]parser.Parameter{
@@ -98,7 +100,7 @@ func TestConvertExpressionToObject(t *testing.T) {
 }
 
 func TestConvertNestedExpressionToObject(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("user", parser.ParameterTypeObject,
 		[]parser.Parameter{
@@ -118,7 +120,7 @@ func TestConvertNestedExpressionToObject(t *testing.T) {
 }
 
 func TestCustomParameterAddedToObject(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("request", parser.ParameterTypeObject, []parser.Parameter{})
 	result, _ := converter.Convert("firstName=Thomas", parameter)
@@ -130,7 +132,7 @@ func TestCustomParameterAddedToObject(t *testing.T) {
 }
 
 func TestConvertObjectArray(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("root", parser.ParameterTypeObject, []parser.Parameter{})
 	result, _ := converter.Convert("nodes[0].id = 1; nodes[0].value = my-value;", parameter)
@@ -147,7 +149,7 @@ func TestConvertObjectArray(t *testing.T) {
 }
 
 func TestMixingObjectAndArrayReturnsError(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("root", parser.ParameterTypeObject, []parser.Parameter{})
 	_, err := converter.Convert("nodes[0].id = 1; nodes.value = my-value;", parameter)
@@ -158,7 +160,7 @@ func TestMixingObjectAndArrayReturnsError(t *testing.T) {
 }
 
 func TestInvalidIndexIsIgnored(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("root", parser.ParameterTypeObject, []parser.Parameter{})
 	result, _ := converter.Convert("nodes[invalid].id = 1", parameter)
@@ -171,7 +173,7 @@ func TestInvalidIndexIsIgnored(t *testing.T) {
 }
 
 func TestNegativeIndexIsIgnored(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("root", parser.ParameterTypeObject, []parser.Parameter{})
 	result, _ := converter.Convert("nodes[-1].id = 1", parameter)
@@ -184,7 +186,7 @@ func TestNegativeIndexIsIgnored(t *testing.T) {
 }
 
 func TestConvertStringAvoidEscapeEqualSign(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("tag", parser.ParameterTypeObject,
 		[]parser.Parameter{
@@ -199,7 +201,7 @@ func TestConvertStringAvoidEscapeEqualSign(t *testing.T) {
 }
 
 func TestConvertPreserveEscapeCharacter(t *testing.T) {
-	converter := newTypeConverter()
+	converter := newTypeConverter(nil)
 
 	parameter := newParameter("values", parser.ParameterTypeStringArray, []parser.Parameter{})
 	result, _ := converter.Convert("..\\path\\myfile.txt,..\\path\\myfile2.txt", parameter)
@@ -227,3 +229,91 @@ func getArrayValue(result interface{}, key string, index int) interface{} {
 func newParameter(name string, t string, parameters []parser.Parameter) parser.Parameter {
 	return *parser.NewParameter(name, t, "", "", name, false, nil, []interface{}{}, false, parameters)
 }
+
+func TestConvertReadsStringFromFileReference(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "name.txt")
+	_ = os.WriteFile(path, []byte("my-name"), 0600)
+	converter := newTypeConverter(nil)
+
+	parameter := newParameter("name", parser.ParameterTypeString, []parser.Parameter{})
+	result, err := converter.Convert("@"+path, parameter)
+
+	if err != nil {
+		t.Errorf("Should not return error, but got: %v", err)
+	}
+	if result != "my-name" {
+		t.Errorf("Result should be file content, but got: %v", result)
+	}
+}
+
+func TestConvertReadsObjectFromStdInReference(t *testing.T) {
+	input := stream.NewMemoryStream("stdin", []byte(`{"foo":"bar"}`))
+	converter := newTypeConverter(input)
+
+	parameter := newParameter("obj", parser.ParameterTypeObject, []parser.Parameter{})
+	result, err := converter.Convert("@-", parameter)
+
+	if err != nil {
+		t.Errorf("Should not return error, but got: %v", err)
+	}
+	if result.(map[string]interface{})["foo"] != "bar" {
+		t.Errorf("Result should be object from stdin, but got: %v", result)
+	}
+}
+
+func TestConvertEscapedFileReferenceReturnsLiteral(t *testing.T) {
+	converter := newTypeConverter(nil)
+
+	parameter := newParameter("name", parser.ParameterTypeString, []parser.Parameter{})
+	result, err := converter.Convert("@@name", parameter)
+
+	if err != nil {
+		t.Errorf("Should not return error, but got: %v", err)
+	}
+	if result != "@name" {
+		t.Errorf("Result should be literal value, but got: %v", result)
+	}
+}
+
+func TestConvertReadsStringArrayFromFileReference(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.txt")
+	_ = os.WriteFile(path, []byte("a,b"), 0600)
+	converter := newTypeConverter(nil)
+
+	parameter := newParameter("values", parser.ParameterTypeStringArray, []parser.Parameter{})
+	result, err := converter.Convert("@"+path, parameter)
+
+	if err != nil {
+		t.Errorf("Should not return error, but got: %v", err)
+	}
+	values := result.([]string)
+	if len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Errorf("Result should be split file content, but got: %v", values)
+	}
+}
+
+func TestConvertEscapedFileReferenceReturnsLiteralStringArray(t *testing.T) {
+	converter := newTypeConverter(nil)
+
+	parameter := newParameter("values", parser.ParameterTypeStringArray, []parser.Parameter{})
+	result, err := converter.Convert("@@a,b", parameter)
+
+	if err != nil {
+		t.Errorf("Should not return error, but got: %v", err)
+	}
+	values := result.([]string)
+	if len(values) != 2 || values[0] != "@a" || values[1] != "b" {
+		t.Errorf("Result should be literal values, but got: %v", values)
+	}
+}
+
+func TestConvertIgnoresFileReferenceForInteger(t *testing.T) {
+	converter := newTypeConverter(nil)
+
+	parameter := newParameter("count", parser.ParameterTypeInteger, []parser.Parameter{})
+	_, err := converter.Convert("@count.txt", parameter)
+
+	if err.Error() != "Cannot convert 'count' value '@count.txt' to integer" {
+		t.Errorf("Should return conversion error, but got: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package commandline
+
+// The ValidationError is returned when the provided command line arguments
+// are invalid.
+type ValidationError struct {
+	err error
+}
+
+func (e ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.err
+}
+
+func NewValidationError(err error) *ValidationError {
+	return &ValidationError{err}
+}
@@ -20,9 +20,17 @@ type Config struct {
 	Header         map[string]string
 	Auth           map[string]interface{}
 	Insecure       bool
+	Proxy          string
+	NoProxy        string
+	CaCertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
 	Debug          bool
 	Output         string
 	ServiceVersion string
+	Retry          RetryConfig
+	RateLimit      RateLimitConfig
+	ConnectionPool ConnectionPoolConfig
 }
 
 const clientIdKey = "clientId"
@@ -157,6 +165,33 @@ func (c *Config) SetInsecure(insecure bool) {
 	c.Insecure = insecure
 }
 
+func (c *Config) SetProxy(proxy string) error {
+	if proxy != "" {
+		_, err := url.Parse(proxy)
+		if err != nil {
+			return fmt.Errorf("Invalid value for 'proxy': %w", err)
+		}
+	}
+	c.Proxy = proxy
+	return nil
+}
+
+func (c *Config) SetNoProxy(noProxy string) {
+	c.NoProxy = noProxy
+}
+
+func (c *Config) SetCaCertFile(caCertFile string) {
+	c.CaCertFile = caCertFile
+}
+
+func (c *Config) SetClientCertFile(clientCertFile string) {
+	c.ClientCertFile = clientCertFile
+}
+
+func (c *Config) SetClientKeyFile(clientKeyFile string) {
+	c.ClientKeyFile = clientKeyFile
+}
+
 func (c *Config) SetDebug(debug bool) {
 	c.Debug = debug
 }
@@ -41,6 +41,11 @@ func (p *ConfigProvider) Update(profileName string, config Config) error {
 	}
 	profile.Uri = urlYaml{config.Uri}
 	profile.Insecure = config.Insecure
+	profile.Proxy = config.Proxy
+	profile.NoProxy = config.NoProxy
+	profile.CaCertFile = config.CaCertFile
+	profile.ClientCertFile = config.ClientCertFile
+	profile.ClientKeyFile = config.ClientKeyFile
 	profile.Debug = config.Debug
 	profile.Organization = config.Organization
 	profile.Tenant = config.Tenant
@@ -72,6 +77,15 @@ func (p *ConfigProvider) convertToConfig(profile profileYaml) Config {
 	if profile.Header == nil {
 		profile.Header = map[string]string{}
 	}
+	retry := RetryConfig{}
+	if profile.Retry != nil {
+		retry = RetryConfig(*profile.Retry)
+	}
+	rateLimit := p.convertToRateLimitConfig(profile.RateLimit)
+	connectionPool := ConnectionPoolConfig{}
+	if profile.ConnectionPool != nil {
+		connectionPool = ConnectionPoolConfig(*profile.ConnectionPool)
+	}
 	return Config{
 		Organization:   profile.Organization,
 		Tenant:         profile.Tenant,
@@ -80,9 +94,32 @@ func (p *ConfigProvider) convertToConfig(profile profileYaml) Config {
 		Header:         profile.Header,
 		Auth:           profile.Auth,
 		Insecure:       profile.Insecure,
+		Proxy:          profile.Proxy,
+		NoProxy:        profile.NoProxy,
+		CaCertFile:     profile.CaCertFile,
+		ClientCertFile: profile.ClientCertFile,
+		ClientKeyFile:  profile.ClientKeyFile,
 		Debug:          profile.Debug,
 		Output:         profile.Output,
 		ServiceVersion: profile.ServiceVersion,
+		Retry:          retry,
+		RateLimit:      rateLimit,
+		ConnectionPool: connectionPool,
+	}
+}
+
+func (p *ConfigProvider) convertToRateLimitConfig(rateLimit *rateLimitYaml) RateLimitConfig {
+	if rateLimit == nil {
+		return RateLimitConfig{Hosts: map[string]HostRateLimitConfig{}}
+	}
+	hosts := map[string]HostRateLimitConfig{}
+	for host, limit := range rateLimit.Hosts {
+		hosts[host] = HostRateLimitConfig(limit)
+	}
+	return RateLimitConfig{
+		RequestsPerSecond: rateLimit.RequestsPerSecond,
+		Burst:             rateLimit.Burst,
+		Hosts:             hosts,
 	}
 }
 
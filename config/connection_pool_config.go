@@ -0,0 +1,10 @@
+package config
+
+// The ConnectionPoolConfig holds the settings for the idle connections which
+// are kept open for reuse. Empty values indicate that the setting is not
+// configured.
+type ConnectionPoolConfig struct {
+	MaxIdle        int
+	MaxIdlePerHost int
+	IdleTimeout    string
+}
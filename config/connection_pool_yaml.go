@@ -0,0 +1,7 @@
+package config
+
+type connectionPoolYaml struct {
+	MaxIdle        int    `yaml:"maxIdle,omitempty"`
+	MaxIdlePerHost int    `yaml:"maxIdlePerHost,omitempty"`
+	IdleTimeout    string `yaml:"idleTimeout,omitempty"`
+}
@@ -9,7 +9,15 @@ type profileYaml struct {
 	Header         map[string]string      `yaml:"header,omitempty"`
 	Auth           map[string]interface{} `yaml:"auth,omitempty"`
 	Insecure       bool                   `yaml:"insecure,omitempty"`
+	Proxy          string                 `yaml:"proxy,omitempty"`
+	NoProxy        string                 `yaml:"noProxy,omitempty"`
+	CaCertFile     string                 `yaml:"caCertFile,omitempty"`
+	ClientCertFile string                 `yaml:"clientCertFile,omitempty"`
+	ClientKeyFile  string                 `yaml:"clientKeyFile,omitempty"`
 	Debug          bool                   `yaml:"debug,omitempty"`
 	Output         string                 `yaml:"output,omitempty"`
 	ServiceVersion string                 `yaml:"serviceVersion,omitempty"`
+	Retry          *retryYaml             `yaml:"retry,omitempty"`
+	RateLimit      *rateLimitYaml         `yaml:"rateLimit,omitempty"`
+	ConnectionPool *connectionPoolYaml    `yaml:"connectionPool,omitempty"`
 }
@@ -0,0 +1,15 @@
+package config
+
+// The RateLimitConfig holds the rate limit settings from the selected profile.
+// The Hosts contain the rate limits for specific service hosts.
+type RateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+	Hosts             map[string]HostRateLimitConfig
+}
+
+// The HostRateLimitConfig holds the rate limit for a single service host.
+type HostRateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
@@ -0,0 +1,12 @@
+package config
+
+type rateLimitYaml struct {
+	RequestsPerSecond float64                      `yaml:"requestsPerSecond,omitempty"`
+	Burst             int                          `yaml:"burst,omitempty"`
+	Hosts             map[string]hostRateLimitYaml `yaml:"hosts,omitempty"`
+}
+
+type hostRateLimitYaml struct {
+	RequestsPerSecond float64 `yaml:"requestsPerSecond,omitempty"`
+	Burst             int     `yaml:"burst,omitempty"`
+}
@@ -0,0 +1,12 @@
+package config
+
+// The RetryConfig holds the retry settings from the selected profile.
+// Empty values indicate that the setting is not configured.
+type RetryConfig struct {
+	MaxAttempts   int
+	InitialDelay  string
+	MaxDelay      string
+	Multiplier    string
+	Jitter        string
+	NonIdempotent bool
+}
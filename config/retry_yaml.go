@@ -0,0 +1,10 @@
+package config
+
+type retryYaml struct {
+	MaxAttempts   int    `yaml:"maxAttempts,omitempty"`
+	InitialDelay  string `yaml:"initialDelay,omitempty"`
+	MaxDelay      string `yaml:"maxDelay,omitempty"`
+	Multiplier    string `yaml:"multiplier,omitempty"`
+	Jitter        string `yaml:"jitter,omitempty"`
+	NonIdempotent bool   `yaml:"nonIdempotent,omitempty"`
+}
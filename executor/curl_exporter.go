@@ -0,0 +1,41 @@
+package executor
+
+import (
+	"strings"
+)
+
+// curlExporter converts the request into a curl command.
+//
+// Example:
+//
+//	curl -X POST 'https://cloud.uipath.com/org/tenant/orchestrator_/odata/Assets' \
+//	  -H 'Content-Type: application/json' \
+//	  --data-raw '{"Name":"my-asset"}'
+type curlExporter struct{}
+
+func (e curlExporter) Export(request exportRequest) string {
+	lines := []string{"curl -X " + request.Method + " " + quotePosix(request.Url)}
+	for _, name := range request.HeaderNames() {
+		for _, value := range request.Header.Values(name) {
+			lines = append(lines, "-H "+quotePosix(name+": "+value))
+		}
+	}
+	for _, field := range request.Form {
+		if field.File != "" {
+			lines = append(lines, "-F "+quotePosix(field.Name+"=@"+field.File))
+		} else {
+			lines = append(lines, "--form-string "+quotePosix(field.Name+"="+field.Value))
+		}
+	}
+	if request.BodyFile != "" {
+		lines = append(lines, "--data-binary "+quotePosix("@"+request.BodyFile))
+	}
+	if request.Body != "" {
+		lines = append(lines, "--data-raw "+quotePosix(request.Body))
+	}
+	return strings.Join(lines, " \\\n  ") + "\n"
+}
+
+func newCurlExporter() *curlExporter {
+	return &curlExporter{}
+}
@@ -0,0 +1,39 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// dryRunRequest describes the HTTP request which would have been sent
+// by the executor when the --dry-run flag is provided.
+type dryRunRequest struct {
+	Method string            `json:"method"`
+	Url    string            `json:"url"`
+	Header map[string]string `json:"header"`
+	Body   interface{}       `json:"body,omitempty"`
+}
+
+func dryRunBody(body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	if utf8.Valid(body) {
+		return string(body)
+	}
+	return fmt.Sprintf("<binary data: %d bytes>", len(body))
+}
+
+func newDryRunRequest(method string, url string, header http.Header, body []byte) *dryRunRequest {
+	values := map[string]string{}
+	for key, value := range header {
+		values[key] = strings.Join(value, ", ")
+	}
+	return &dryRunRequest{method, url, values, dryRunBody(body)}
+}
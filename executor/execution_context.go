@@ -3,6 +3,7 @@ package executor
 import (
 	"net/url"
 
+	"github.com/UiPath/uipathcli/parser"
 	"github.com/UiPath/uipathcli/plugin"
 	"github.com/UiPath/uipathcli/utils/stream"
 )
@@ -21,7 +22,13 @@ type ExecutionContext struct {
 	AuthConfig   map[string]interface{}
 	IdentityUri  url.URL
 	Plugin       plugin.CommandPlugin
+	Pagination   *parser.OperationPagination
 	Debug        bool
+	Paginate     bool
+	DryRun       bool
+	DryRunAuth   bool
+	Export       string
+	ExportToken  bool
 	Settings     ExecutionSettings
 }
 
@@ -37,7 +44,13 @@ func NewExecutionContext(
 	authConfig map[string]interface{},
 	identityUri url.URL,
 	plugin plugin.CommandPlugin,
+	pagination *parser.OperationPagination,
 	debug bool,
+	paginate bool,
+	dryRun bool,
+	dryRunAuth bool,
+	export string,
+	exportToken bool,
 	settings ExecutionSettings) *ExecutionContext {
 	return &ExecutionContext{
 		organization,
@@ -51,7 +64,13 @@ func NewExecutionContext(
 		authConfig,
 		identityUri,
 		plugin,
+		pagination,
 		debug,
+		paginate,
+		dryRun,
+		dryRunAuth,
+		export,
+		exportToken,
 		settings,
 	}
 }
@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"net/http"
+	"sort"
+)
+
+// exportRequest describes the HTTP request which is converted into a command
+// by the request exporters when the --export flag is provided.
+//
+// The body is either serialized in Body, references a file on disk using BodyFile
+// or consists of multipart form fields.
+type exportRequest struct {
+	Method   string
+	Url      string
+	Header   http.Header
+	Body     string
+	BodyFile string
+	Form     []exportFormField
+}
+
+// exportFormField is a multipart form field which either contains a value or
+// references a file on disk.
+type exportFormField struct {
+	Name  string
+	Value string
+	File  string
+}
+
+func (r exportRequest) HeaderNames() []string {
+	names := []string{}
+	for name := range r.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newExportRequest(method string, url string, header http.Header, body string, bodyFile string, form []exportFormField) *exportRequest {
+	return &exportRequest{method, url, header, body, bodyFile, form}
+}
+
+func newExportFormValue(name string, value string) *exportFormField {
+	return &exportFormField{name, value, ""}
+}
+
+func newExportFormFile(name string, file string) *exportFormField {
+	return &exportFormField{name, "", file}
+}
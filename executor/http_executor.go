@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -15,12 +14,15 @@ import (
 	"github.com/UiPath/uipathcli/auth"
 	"github.com/UiPath/uipathcli/log"
 	"github.com/UiPath/uipathcli/output"
+	"github.com/UiPath/uipathcli/parser"
 	"github.com/UiPath/uipathcli/utils/converter"
 	"github.com/UiPath/uipathcli/utils/network"
 	"github.com/UiPath/uipathcli/utils/stream"
 	"github.com/UiPath/uipathcli/utils/visualization"
 )
 
+const redactedValue = "***"
+
 const NotConfiguredErrorTemplate = `Run config command to set organization and tenant:
 
     uipath config
@@ -140,6 +142,9 @@ func (e HttpExecutor) authenticatorContext(ctx ExecutionContext, logger log.Logg
 		ctx.IdentityUri,
 		ctx.Settings.OperationId,
 		ctx.Settings.Insecure,
+		ctx.Settings.RateLimit,
+		ctx.Settings.Connection,
+		ctx.Settings.Tracer,
 		ctx.Debug,
 		authRequest,
 		logger,
@@ -152,7 +157,7 @@ func (e HttpExecutor) executeAuthenticators(ctx ExecutionContext, logger log.Log
 		authContext := e.authenticatorContext(ctx, logger, url)
 		result := authProvider.Auth(authContext)
 		if result.Error != "" {
-			return nil, errors.New(result.Error)
+			return nil, auth.NewAuthenticationError(result.Error)
 		}
 		if result.Token != nil {
 			token = result.Token
@@ -206,14 +211,18 @@ func (e HttpExecutor) writeInputBody(bodyWriter *io.PipeWriter, input stream.Str
 	}()
 }
 
+func (e HttpExecutor) urlEncode(parameters []ExecutionParameter) string {
+	queryStringBuilder := converter.NewQueryStringBuilder()
+	for _, parameter := range parameters {
+		queryStringBuilder.Add(parameter.Name, parameter.Value)
+	}
+	return queryStringBuilder.Build()
+}
+
 func (e HttpExecutor) writeUrlEncodedBody(bodyWriter *io.PipeWriter, parameters []ExecutionParameter, cancel context.CancelCauseFunc) {
 	go func() {
 		defer func() { _ = bodyWriter.Close() }()
-		queryStringBuilder := converter.NewQueryStringBuilder()
-		for _, parameter := range parameters {
-			queryStringBuilder.Add(parameter.Name, parameter.Value)
-		}
-		queryString := queryStringBuilder.Build()
+		queryString := e.urlEncode(parameters)
 		_, err := bodyWriter.Write([]byte(queryString))
 		if err != nil {
 			cancel(err)
@@ -277,7 +286,11 @@ func (e HttpExecutor) httpClientSettings(ctx ExecutionContext) network.HttpClien
 		ctx.Settings.OperationId,
 		ctx.Settings.Header,
 		ctx.Settings.Timeout,
-		ctx.Settings.MaxAttempts,
+		ctx.Settings.Retry,
+		ctx.Settings.RetryNonIdempotent,
+		ctx.Settings.RateLimit,
+		ctx.Settings.Connection,
+		ctx.Settings.Tracer,
 		ctx.Settings.Insecure)
 }
 
@@ -288,47 +301,422 @@ func (e HttpExecutor) toAuthorization(token *auth.AuthToken) *network.Authorizat
 	return network.NewAuthorization(token.Type, token.Value)
 }
 
-func (e HttpExecutor) Call(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
-	uri, err := e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), ctx.Parameters.Query())
+func (e HttpExecutor) createRequest(ctx ExecutionContext, uri url.URL, token *auth.AuthToken, body io.Reader, contentType string, contentLength int64) *network.HttpRequest {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	e.addHeaders(header, ctx.Parameters.Header())
+	return network.NewHttpRequest(ctx.Method, uri.String(), e.toAuthorization(token), header, body, contentLength)
+}
+
+func (e HttpExecutor) redactAuthorization(header http.Header) {
+	authorization := header.Get("Authorization")
+	if authorization == "" {
+		return
+	}
+	scheme, _, found := strings.Cut(authorization, " ")
+	if !found {
+		header.Set("Authorization", redactedValue)
+		return
+	}
+	header.Set("Authorization", scheme+" "+redactedValue)
+}
+
+func (e HttpExecutor) dryRun(ctx ExecutionContext, uri url.URL, writer output.OutputWriter, logger log.Logger) error {
+	bodyContext, cancel := context.WithCancelCause(context.Background())
+	bodyReader, contentType, contentLength, _ := e.writeBody(ctx, cancel)
+	defer func() { _ = bodyReader.Close() }()
+
+	var token *auth.AuthToken = nil
+	if ctx.DryRunAuth {
+		auth, err := e.executeAuthenticators(ctx, logger, uri.String())
+		if err != nil {
+			return err
+		}
+		token = auth.Token
+	}
+
+	request := e.createRequest(ctx, uri, token, bodyReader, contentType, contentLength)
+	client := network.NewHttpClient(logger, e.httpClientSettings(ctx))
+	header := client.Header(request)
+	e.redactAuthorization(header)
+
+	body, err := io.ReadAll(bodyReader)
 	if err != nil {
-		return err
+		return fmt.Errorf("Error writing body: %w", err)
 	}
-	context, cancel := context.WithCancelCause(context.Background())
-	bodyReader, contentType, contentLength, size := e.writeBody(ctx, cancel)
-	uploadBar := visualization.NewProgressBar(logger)
-	uploadReader := e.progressReader("uploading...", "completing  ", bodyReader, size, uploadBar)
-	defer uploadBar.Remove()
+	if cause := context.Cause(bodyContext); cause != nil {
+		return cause
+	}
+	data, err := json.Marshal(newDryRunRequest(request.Method, request.URL, header, body))
+	if err != nil {
+		return fmt.Errorf("Error creating dry run output: %w", err)
+	}
+	return writer.WriteResponse(*output.NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewReader(data)))
+}
 
-	auth, err := e.executeAuthenticators(ctx, logger, uri.String())
+func (e HttpExecutor) firstRequestUri(ctx ExecutionContext) (*url.URL, error) {
+	queryParameters := ctx.Parameters.Query()
+	if ctx.Paginate && ctx.Pagination == nil {
+		queryParameters = e.odataQueryParameters(queryParameters)
+	}
+	return e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), queryParameters)
+}
+
+func (e HttpExecutor) callDryRun(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	uri, err := e.firstRequestUri(ctx)
 	if err != nil {
 		return err
 	}
+	return e.dryRun(ctx, *uri, writer, logger)
+}
+
+func (e HttpExecutor) readStream(input stream.Stream) (string, error) {
+	data, err := input.Data()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = data.Close() }()
+	result, err := io.ReadAll(data)
+	if err != nil {
+		return "", fmt.Errorf("Error reading '%s': %w", input.Name(), err)
+	}
+	return string(result), nil
+}
+
+func (e HttpExecutor) exportForm(parameters []ExecutionParameter) ([]exportFormField, error) {
+	result := []exportFormField{}
+	for _, parameter := range parameters {
+		switch v := parameter.Value.(type) {
+		case string:
+			result = append(result, *newExportFormValue(parameter.Name, v))
+		case *stream.FileStream:
+			result = append(result, *newExportFormFile(parameter.Name, v.Path()))
+		case stream.Stream:
+			value, err := e.readStream(v)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *newExportFormValue(parameter.Name, value))
+		}
+	}
+	return result, nil
+}
+
+func (e HttpExecutor) createExportRequest(ctx ExecutionContext, uri url.URL, token *auth.AuthToken) (*exportRequest, error) {
+	body := ""
+	bodyFile := ""
+	form := []exportFormField{}
+	contentType := ctx.ContentType
+	var err error
+
+	formParameters := ctx.Parameters.Form()
+	bodyParameters := ctx.Parameters.Body()
+	if fileStream, ok := ctx.Input.(*stream.FileStream); ok {
+		bodyFile = fileStream.Path()
+	} else if ctx.Input != nil {
+		body, err = e.readStream(ctx.Input)
+	} else if len(formParameters) > 0 {
+		contentType = ""
+		form, err = e.exportForm(formParameters)
+	} else if len(bodyParameters) > 0 && ctx.ContentType == "application/x-www-form-urlencoded" {
+		body = e.urlEncode(bodyParameters)
+	} else if len(bodyParameters) > 0 {
+		buffer := bytes.Buffer{}
+		err = e.serializeJson(&buffer, bodyParameters)
+		body = buffer.String()
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	header := http.Header{}
 	if contentType != "" {
 		header.Set("Content-Type", contentType)
 	}
 	e.addHeaders(header, ctx.Parameters.Header())
-	request := network.NewHttpRequest(ctx.Method, uri.String(), e.toAuthorization(auth.Token), header, uploadReader, contentLength)
+	for key, value := range ctx.Settings.Header {
+		header.Set(key, value)
+	}
+	if token != nil {
+		header.Set("Authorization", fmt.Sprintf("%s %s", token.Type, token.Value))
+	}
+	return newExportRequest(ctx.Method, uri.String(), header, body, bodyFile, form), nil
+}
 
-	client := network.NewHttpClient(logger, e.httpClientSettings(ctx))
-	response, err := client.SendWithContext(request, context)
+func (e HttpExecutor) callExport(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	exporter, err := newRequestExporter(ctx.Export)
+	if err != nil {
+		return err
+	}
+	uri, err := e.firstRequestUri(ctx)
 	if err != nil {
 		return err
 	}
+	var token *auth.AuthToken = nil
+	if ctx.ExportToken {
+		auth, err := e.executeAuthenticators(ctx, logger, uri.String())
+		if err != nil {
+			return err
+		}
+		token = auth.Token
+	}
+	request, err := e.createExportRequest(ctx, *uri, token)
+	if err != nil {
+		return err
+	}
+	command := exporter.Export(*request)
+	return writer.WriteResponse(*output.NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, strings.NewReader(command)))
+}
+
+func (e HttpExecutor) sendRequest(ctx ExecutionContext, uri url.URL, logger log.Logger) (*network.HttpResponse, error) {
+	context, cancel := context.WithCancelCause(context.Background())
+	bodyReader, contentType, contentLength, size := e.writeBody(ctx, cancel)
+	uploadBar := visualization.NewProgressBar(logger)
+	uploadReader := e.progressReader("uploading...", "completing  ", bodyReader, size, uploadBar)
+	defer uploadBar.Remove()
+
+	auth, err := e.executeAuthenticators(ctx, logger, uri.String())
+	if err != nil {
+		return nil, err
+	}
+
+	request := e.createRequest(ctx, uri, auth.Token, uploadReader, contentType, contentLength)
+	client := network.NewHttpClient(logger, e.httpClientSettings(ctx))
+	return client.SendWithContext(request, context)
+}
+
+func (e HttpExecutor) send(ctx ExecutionContext, uri url.URL, logger log.Logger) (*network.HttpResponse, []byte, error) {
+	response, err := e.sendRequest(ctx, uri, logger)
+	if err != nil {
+		return nil, nil, err
+	}
 	defer func() { _ = response.Body.Close() }()
 	downloadBar := visualization.NewProgressBar(logger)
 	downloadReader := e.progressReader("downloading...", "completing    ", response.Body, response.ContentLength, downloadBar)
 	defer downloadBar.Remove()
 	body, err := io.ReadAll(downloadReader)
 	if err != nil {
-		return fmt.Errorf("Error reading response body: %w", err)
+		return nil, nil, fmt.Errorf("Error reading response body: %w", err)
+	}
+	return response, body, nil
+}
+
+func (e HttpExecutor) findParameter(parameters []ExecutionParameter, name string) *ExecutionParameter {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return &parameter
+		}
+	}
+	return nil
+}
+
+func (e HttpExecutor) setParameter(parameters []ExecutionParameter, name string, value interface{}) []ExecutionParameter {
+	result := []ExecutionParameter{}
+	for _, parameter := range parameters {
+		if parameter.Name != name {
+			result = append(result, parameter)
+		}
+	}
+	return append(result, *NewExecutionParameter(name, value, parser.ParameterInQuery))
+}
+
+func (e HttpExecutor) odataSkip(queryParameters []ExecutionParameter) int {
+	parameter := e.findParameter(queryParameters, odataSkipParameter)
+	if parameter == nil {
+		return 0
+	}
+	skip, ok := parameter.Value.(int)
+	if !ok {
+		return 0
+	}
+	return skip
+}
+
+func (e HttpExecutor) odataQueryParameters(queryParameters []ExecutionParameter) []ExecutionParameter {
+	if e.findParameter(queryParameters, odataCountParameter) != nil {
+		return queryParameters
+	}
+	return e.setParameter(queryParameters, odataCountParameter, true)
+}
+
+func (e HttpExecutor) nextPageUri(ctx ExecutionContext, uri url.URL, page odataPage, queryParameters []ExecutionParameter, skip int) (*url.URL, error) {
+	if page.NextLink != "" {
+		nextLink, err := url.Parse(page.NextLink)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s '%s': %w", odataNextLinkField, page.NextLink, err)
+		}
+		nextUri := uri.ResolveReference(nextLink)
+		if nextUri.String() == uri.String() {
+			return nil, nil
+		}
+		return nextUri, nil
+	}
+	if !page.HasCount || skip >= page.Count {
+		return nil, nil
+	}
+	queryParameters = e.setParameter(queryParameters, odataSkipParameter, skip)
+	return e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), queryParameters)
+}
+
+func (e HttpExecutor) writePages(writer output.OutputWriter, response network.HttpResponse, data map[string]interface{}, itemsField string, continuationField string, items []interface{}) error {
+	if output.IsStreaming(writer) {
+		return nil
+	}
+	data[itemsField] = items
+	delete(data, continuationField)
+	body, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("Error merging pages: %w", err)
 	}
-	err = writer.WriteResponse(*output.NewResponseInfo(response.StatusCode, response.Status, response.Proto, response.Header, bytes.NewReader(body)))
+	return writer.WriteResponse(*output.NewResponseInfo(response.StatusCode, response.Status, response.Proto, response.Header, bytes.NewReader(body)))
+}
+
+func (e HttpExecutor) writeResponse(writer output.OutputWriter, response network.HttpResponse, body []byte) error {
+	return writer.WriteResponse(*output.NewResponseInfo(response.StatusCode, response.Status, response.Proto, response.Header, bytes.NewReader(body)))
+}
+
+func (e HttpExecutor) writeHttpError(writer output.OutputWriter, response network.HttpResponse, body []byte) error {
+	err := e.writeResponse(writer, response, body)
 	if err != nil {
 		return err
 	}
-	return nil
+	return network.NewHttpError(response.StatusCode, response.Status, response.Header, body)
+}
+
+func (e HttpExecutor) isSuccess(response network.HttpResponse) bool {
+	return response.StatusCode >= 200 && response.StatusCode < 300
+}
+
+func (e HttpExecutor) callOdataPaginated(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	queryParameters := e.odataQueryParameters(ctx.Parameters.Query())
+	uri, err := e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), queryParameters)
+	if err != nil {
+		return err
+	}
+	skip := e.odataSkip(queryParameters)
+
+	var data map[string]interface{}
+	items := []interface{}{}
+	for {
+		response, body, err := e.send(ctx, *uri, logger)
+		if err != nil {
+			return err
+		}
+		if !e.isSuccess(*response) {
+			return e.writeHttpError(writer, *response, body)
+		}
+		page := parseOdataPage(body)
+		if page == nil {
+			return e.writeResponse(writer, *response, body)
+		}
+		if output.IsStreaming(writer) {
+			err = e.writeResponse(writer, *response, body)
+			if err != nil {
+				return err
+			}
+		} else {
+			items = append(items, page.Items...)
+		}
+		if data == nil {
+			data = page.Data
+		}
+		skip += len(page.Items)
+
+		if len(page.Items) == 0 {
+			return e.writePages(writer, *response, data, odataValueField, odataNextLinkField, items)
+		}
+		uri, err = e.nextPageUri(ctx, *uri, *page, queryParameters, skip)
+		if err != nil {
+			return err
+		}
+		if uri == nil {
+			return e.writePages(writer, *response, data, odataValueField, odataNextLinkField, items)
+		}
+	}
+}
+
+func (e HttpExecutor) callTokenPaginated(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	pagination := *ctx.Pagination
+	queryParameters := ctx.Parameters.Query()
+	uri, err := e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), queryParameters)
+	if err != nil {
+		return err
+	}
+
+	var data map[string]interface{}
+	items := []interface{}{}
+	token := ""
+	for {
+		response, body, err := e.send(ctx, *uri, logger)
+		if err != nil {
+			return err
+		}
+		if !e.isSuccess(*response) {
+			return e.writeHttpError(writer, *response, body)
+		}
+		page := parseTokenPage(body, pagination)
+		if page == nil {
+			return e.writeResponse(writer, *response, body)
+		}
+		if output.IsStreaming(writer) {
+			err = e.writeResponse(writer, *response, body)
+			if err != nil {
+				return err
+			}
+		} else {
+			items = append(items, page.Items...)
+		}
+		if data == nil {
+			data = page.Data
+		}
+
+		if page.Token == "" || page.Token == token {
+			return e.writePages(writer, *response, data, pagination.ItemsField, pagination.TokenField, items)
+		}
+		token = page.Token
+		queryParameters = e.setParameter(queryParameters, pagination.TokenParameter, token)
+		uri, err = e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), queryParameters)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (e HttpExecutor) Call(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	if ctx.Export != "" {
+		return e.callExport(ctx, writer, logger)
+	}
+	if ctx.DryRun {
+		return e.callDryRun(ctx, writer, logger)
+	}
+	if ctx.Paginate && ctx.Pagination != nil {
+		return e.callTokenPaginated(ctx, writer, logger)
+	}
+	if ctx.Paginate {
+		return e.callOdataPaginated(ctx, writer, logger)
+	}
+	uri, err := e.formatUri(ctx.BaseUri, ctx.Route, e.pathParameters(ctx), ctx.Parameters.Query())
+	if err != nil {
+		return err
+	}
+	response, err := e.sendRequest(ctx, *uri, logger)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	downloadBar := visualization.NewProgressBar(logger)
+	downloadReader := e.progressReader("downloading...", "completing    ", response.Body, response.ContentLength, downloadBar)
+	defer downloadBar.Remove()
+	if !e.isSuccess(*response) {
+		body, err := io.ReadAll(downloadReader)
+		if err != nil {
+			return fmt.Errorf("Error reading response body: %w", err)
+		}
+		return e.writeHttpError(writer, *response, body)
+	}
+	return writer.WriteResponse(*output.NewResponseInfo(response.StatusCode, response.Status, response.Proto, response.Header, downloadReader))
 }
 
 func NewHttpExecutor(authenticators []auth.Authenticator) *HttpExecutor {
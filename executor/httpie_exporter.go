@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"strings"
+)
+
+// httpieExporter converts the request into an HTTPie command.
+//
+// Example:
+//
+//	http POST 'https://cloud.uipath.com/org/tenant/orchestrator_/odata/Assets' \
+//	  'Content-Type:application/json' \
+//	  --raw '{"Name":"my-asset"}'
+type httpieExporter struct{}
+
+func (e httpieExporter) Export(request exportRequest) string {
+	lines := []string{"http " + request.Method + " " + quotePosix(request.Url)}
+	if len(request.Form) > 0 {
+		lines[0] = "http --multipart " + request.Method + " " + quotePosix(request.Url)
+	}
+	for _, name := range request.HeaderNames() {
+		for _, value := range request.Header.Values(name) {
+			lines = append(lines, quotePosix(name+":"+value))
+		}
+	}
+	for _, field := range request.Form {
+		if field.File != "" {
+			lines = append(lines, quotePosix(field.Name+"@"+field.File))
+		} else {
+			lines = append(lines, quotePosix(field.Name+"="+field.Value))
+		}
+	}
+	if request.Body != "" {
+		lines = append(lines, "--raw "+quotePosix(request.Body))
+	}
+	result := strings.Join(lines, " \\\n  ")
+	if request.BodyFile != "" {
+		result += " \\\n  < " + quotePosix(request.BodyFile)
+	}
+	return result + "\n"
+}
+
+func newHttpieExporter() *httpieExporter {
+	return &httpieExporter{}
+}
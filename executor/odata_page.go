@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"encoding/json"
+)
+
+const odataValueField = "value"
+const odataCountField = "@odata.count"
+const odataNextLinkField = "@odata.nextLink"
+const odataSkipParameter = "$skip"
+const odataCountParameter = "$count"
+
+// The odataPage represents a single page of an OData collection response.
+//
+// OData services return the items of the collection in the value array and
+// provide information how to retrieve the next page using the @odata.count
+// and @odata.nextLink properties.
+type odataPage struct {
+	Data     map[string]interface{}
+	Items    []interface{}
+	Count    int
+	HasCount bool
+	NextLink string
+}
+
+func (p odataPage) countValue(value interface{}) (int, bool) {
+	count, ok := value.(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(count), true
+}
+
+func (p odataPage) nextLinkValue(value interface{}) string {
+	nextLink, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	return nextLink
+}
+
+func parseOdataPage(body []byte) *odataPage {
+	var data map[string]interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return nil
+	}
+	items, ok := data[odataValueField].([]interface{})
+	if !ok {
+		return nil
+	}
+	page := odataPage{Data: data, Items: items}
+	page.Count, page.HasCount = page.countValue(data[odataCountField])
+	page.NextLink = page.nextLinkValue(data[odataNextLinkField])
+	return &page
+}
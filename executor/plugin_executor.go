@@ -24,6 +24,9 @@ func (e PluginExecutor) authenticatorContext(ctx ExecutionContext, logger log.Lo
 		ctx.IdentityUri,
 		ctx.Settings.OperationId,
 		ctx.Settings.Insecure,
+		ctx.Settings.RateLimit,
+		ctx.Settings.Connection,
+		ctx.Settings.Tracer,
 		ctx.Debug,
 		authRequest,
 		logger)
@@ -35,7 +38,7 @@ func (e PluginExecutor) executeAuthenticators(ctx ExecutionContext, logger log.L
 		authContext := e.authenticatorContext(ctx, logger)
 		result := authProvider.Auth(authContext)
 		if result.Error != "" {
-			return nil, errors.New(result.Error)
+			return nil, auth.NewAuthenticationError(result.Error)
 		}
 		if result.Token != nil {
 			token = result.Token
@@ -60,9 +63,20 @@ func (e PluginExecutor) pluginAuth(auth *auth.AuthenticatorResult) plugin.AuthRe
 }
 
 func (e PluginExecutor) Call(ctx ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
-	auth, err := e.executeAuthenticators(ctx, logger)
-	if err != nil {
-		return err
+	if ctx.Export != "" {
+		return NewValidationError(errors.New("Export is not supported for this command"))
+	}
+	command := ctx.Plugin.Command()
+	if ctx.DryRun && !command.DryRun {
+		return NewValidationError(errors.New("Dry run is not supported for this command"))
+	}
+	auth := auth.AuthenticatorSuccess(nil)
+	if !ctx.DryRun || ctx.DryRunAuth || command.DryRunAuth {
+		var err error
+		auth, err = e.executeAuthenticators(ctx, logger)
+		if err != nil {
+			return err
+		}
 	}
 
 	pluginAuth := e.pluginAuth(auth)
@@ -76,7 +90,8 @@ func (e PluginExecutor) Call(ctx ExecutionContext, writer output.OutputWriter, l
 		ctx.Input,
 		pluginParams,
 		ctx.Debug,
-		*plugin.NewExecutionSettings(ctx.Settings.OperationId, ctx.Settings.Header, ctx.Settings.Timeout, ctx.Settings.MaxAttempts, ctx.Settings.Insecure))
+		ctx.DryRun,
+		*plugin.NewExecutionSettings(ctx.Settings.OperationId, ctx.Settings.Header, ctx.Settings.Timeout, ctx.Settings.Retry, ctx.Settings.RetryNonIdempotent, ctx.Settings.RateLimit, ctx.Settings.Connection, ctx.Settings.Tracer, ctx.Settings.Insecure, ctx.Settings.OutputFile))
 	return ctx.Plugin.Execute(*pluginContext, writer, logger)
 }
 
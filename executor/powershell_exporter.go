@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"net/http"
+	"strings"
+)
+
+// powerShellExporter converts the request into a PowerShell Invoke-RestMethod
+// command.
+//
+// Example:
+//
+//	Invoke-RestMethod -Method POST `
+//	  -Uri 'https://cloud.uipath.com/org/tenant/orchestrator_/odata/Assets' `
+//	  -ContentType 'application/json' `
+//	  -Body '{"Name":"my-asset"}'
+type powerShellExporter struct{}
+
+func (e powerShellExporter) quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (e powerShellExporter) headers(request exportRequest) string {
+	entries := []string{}
+	for _, name := range request.HeaderNames() {
+		if http.CanonicalHeaderKey(name) == "Content-Type" {
+			continue
+		}
+		value := strings.Join(request.Header.Values(name), ", ")
+		entries = append(entries, e.quote(name)+" = "+e.quote(value))
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return "@{ " + strings.Join(entries, "; ") + " }"
+}
+
+func (e powerShellExporter) form(request exportRequest) string {
+	entries := []string{}
+	for _, field := range request.Form {
+		if field.File != "" {
+			entries = append(entries, e.quote(field.Name)+" = Get-Item -Path "+e.quote(field.File))
+		} else {
+			entries = append(entries, e.quote(field.Name)+" = "+e.quote(field.Value))
+		}
+	}
+	return "@{ " + strings.Join(entries, "; ") + " }"
+}
+
+func (e powerShellExporter) Export(request exportRequest) string {
+	lines := []string{
+		"Invoke-RestMethod -Method " + request.Method,
+		"-Uri " + e.quote(request.Url),
+	}
+	headers := e.headers(request)
+	if headers != "" {
+		lines = append(lines, "-Headers "+headers)
+	}
+	contentType := request.Header.Get("Content-Type")
+	if contentType != "" {
+		lines = append(lines, "-ContentType "+e.quote(contentType))
+	}
+	if len(request.Form) > 0 {
+		lines = append(lines, "-Form "+e.form(request))
+	}
+	if request.BodyFile != "" {
+		lines = append(lines, "-InFile "+e.quote(request.BodyFile))
+	}
+	if request.Body != "" {
+		lines = append(lines, "-Body "+e.quote(request.Body))
+	}
+	return strings.Join(lines, " `\n  ") + "\n"
+}
+
+func newPowerShellExporter() *powerShellExporter {
+	return &powerShellExporter{}
+}
@@ -0,0 +1,40 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+const ExportFormatCurl = "curl"
+const ExportFormatPowerShell = "powershell"
+const ExportFormatHttpie = "httpie"
+
+// ExportFormats contains the supported formats for exporting requests.
+var ExportFormats = []string{
+	ExportFormatCurl,
+	ExportFormatPowerShell,
+	ExportFormatHttpie,
+}
+
+// requestExporter converts the request into a command which can be copied and
+// executed without the CLI.
+type requestExporter interface {
+	Export(request exportRequest) string
+}
+
+func newRequestExporter(format string) (requestExporter, error) {
+	switch format {
+	case ExportFormatCurl:
+		return newCurlExporter(), nil
+	case ExportFormatPowerShell:
+		return newPowerShellExporter(), nil
+	case ExportFormatHttpie:
+		return newHttpieExporter(), nil
+	}
+	return nil, fmt.Errorf("Invalid export format '%s', allowed values: %s", format, strings.Join(ExportFormats, ", "))
+}
+
+// quotePosix quotes the value for POSIX compatible shells like bash or zsh.
+func quotePosix(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
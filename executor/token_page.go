@@ -0,0 +1,32 @@
+package executor
+
+import (
+	"encoding/json"
+
+	"github.com/UiPath/uipathcli/parser"
+)
+
+// The tokenPage represents a single page of a response which uses continuation
+// tokens for pagination.
+//
+// The operation pagination defines in which field the items and the token
+// for retrieving the next page are returned.
+type tokenPage struct {
+	Data  map[string]interface{}
+	Items []interface{}
+	Token string
+}
+
+func parseTokenPage(body []byte, pagination parser.OperationPagination) *tokenPage {
+	var data map[string]interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return nil
+	}
+	items, ok := data[pagination.ItemsField].([]interface{})
+	if !ok {
+		return nil
+	}
+	token, _ := data[pagination.TokenField].(string)
+	return &tokenPage{data, items, token}
+}
@@ -0,0 +1,19 @@
+package executor
+
+// The ValidationError is returned when the command does not support the
+// provided arguments.
+type ValidationError struct {
+	err error
+}
+
+func (e ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.err
+}
+
+func NewValidationError(err error) *ValidationError {
+	return &ValidationError{err}
+}
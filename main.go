@@ -19,6 +19,7 @@ import (
 	"github.com/UiPath/uipathcli/plugin"
 	plugin_digitizer "github.com/UiPath/uipathcli/plugin/digitizer"
 	plugin_orchestrator_download "github.com/UiPath/uipathcli/plugin/orchestrator/download"
+	plugin_orchestrator_sync "github.com/UiPath/uipathcli/plugin/orchestrator/synchronize"
 	plugin_orchestrator_upload "github.com/UiPath/uipathcli/plugin/orchestrator/upload"
 	plugin_studio_analyze "github.com/UiPath/uipathcli/plugin/studio/analyze"
 	plugin_studio_pack "github.com/UiPath/uipathcli/plugin/studio/pack"
@@ -73,6 +74,7 @@ func main() {
 				plugin_digitizer.NewDigitizeCommand(),
 				plugin_orchestrator_download.NewDownloadCommand(),
 				plugin_orchestrator_upload.NewUploadCommand(),
+				plugin_orchestrator_sync.NewSyncCommand(),
 				plugin_studio_pack.NewPackagePackCommand(),
 				plugin_studio_analyze.NewPackageAnalyzeCommand(),
 				plugin_studio_restore.NewPackageRestoreCommand(),
@@ -88,6 +90,6 @@ func main() {
 	input := stdIn()
 	err = cli.Run(context.Background(), os.Args, input)
 	if err != nil {
-		os.Exit(1)
+		os.Exit(commandline.ExitCode(err))
 	}
 }
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+const csvNestedSeparator = "."
+
+// The CsvOutputWriter formats the CLI output as comma-separated values which
+// can be imported in spreadsheet applications like Excel.
+//
+// It is used when the --output csv or --output tsv parameter is provided. Values
+// are quoted according to RFC 4180 and nested objects are flattened into dotted
+// column names. Arrays nested under the OData "value" property are unwrapped
+// automatically. The columns are sorted by name unless they are explicitly
+// provided using the --columns parameter.
+// Example:
+// Id,Name,Robot.Id
+// 1,"Invoice, Process",5
+type CsvOutputWriter struct {
+	output      io.Writer
+	transformer Transformer
+	columns     []string
+	separator   rune
+}
+
+func (w CsvOutputWriter) flatten(prefix string, value map[string]interface{}, result map[string]interface{}) {
+	for key, value := range value {
+		name := key
+		if prefix != "" {
+			name = prefix + csvNestedSeparator + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			w.flatten(name, nested, result)
+			continue
+		}
+		result[name] = value
+	}
+}
+
+func (w CsvOutputWriter) createRecords(value interface{}) [][]string {
+	switch result := unwrapODataValue(value).(type) {
+	case map[string]interface{}:
+		return w.createObjectRecords([]interface{}{result})
+	case []interface{}:
+		for _, item := range result {
+			if _, ok := item.(map[string]interface{}); !ok {
+				return formatValueRows(result, formatColumnValue)
+			}
+		}
+		return w.createObjectRecords(result)
+	default:
+		return [][]string{{formatColumnValue(result)}}
+	}
+}
+
+func (w CsvOutputWriter) createObjectRecords(array []interface{}) [][]string {
+	rows := []map[string]interface{}{}
+	for _, item := range array {
+		row := map[string]interface{}{}
+		w.flatten("", item.(map[string]interface{}), row)
+		rows = append(rows, row)
+	}
+	columns := w.columns
+	if len(columns) == 0 {
+		columns = collectColumns(rows, true)
+	}
+	if len(columns) == 0 {
+		return [][]string{}
+	}
+	return append([][]string{columns}, formatObjectRows(rows, columns, formatColumnValue)...)
+}
+
+func (w CsvOutputWriter) write(value interface{}) error {
+	writer := csv.NewWriter(w.output)
+	writer.Comma = w.separator
+	return writer.WriteAll(w.createRecords(value))
+}
+
+func (w CsvOutputWriter) WriteResponse(response ResponseInfo) error {
+	return writeRowsResponse(w.output, w.transformer, response, w.write)
+}
+
+func NewCsvOutputWriter(output io.Writer, transformer Transformer, columns []string) *CsvOutputWriter {
+	return &CsvOutputWriter{output, transformer, columns, ','}
+}
+
+func NewTsvOutputWriter(output io.Writer, transformer Transformer, columns []string) *CsvOutputWriter {
+	return &CsvOutputWriter{output, transformer, columns, '\t'}
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func writeCsv(t *testing.T, writer OutputWriter, body string) {
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(body)))
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+}
+
+func TestCsvWriterOutputsErrorStatusWhenResponseIsFailure(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewCsvOutputWriter(&output, NewDefaultTransformer(), []string{})
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusBadRequest, "400 BadRequest", "HTTP/1.1", map[string][]string{}, bytes.NewReader([]byte{})))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "HTTP/1.1 400 BadRequest\n" {
+		t.Errorf("Should show HTTP error status, but got: %v", output.String())
+	}
+}
+
+func TestCsvWriterOutputsHeaderAndRowsSortedByColumn(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewCsvOutputWriter(&output, NewDefaultTransformer(), []string{})
+
+	writeCsv(t, writer, `[{"Name":"a","Id":1},{"Id":2,"State":"Running"}]`)
+
+	expected := "Id,Name,State\n1,a,\n2,,Running\n"
+	if output.String() != expected {
+		t.Errorf("Should show csv with union of keys, but got: %v", output.String())
+	}
+}
+
+func TestCsvWriterQuotesValues(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewCsvOutputWriter(&output, NewDefaultTransformer(), []string{})
+
+	writeCsv(t, writer, `{"Name":"Invoice, \"Process\"","Description":"line1\nline2"}`)
+
+	expected := "Description,Name\n\"line1\nline2\",\"Invoice, \"\"Process\"\"\"\n"
+	if output.String() != expected {
+		t.Errorf("Should quote values according to RFC 4180, but got: %v", output.String())
+	}
+}
+
+func TestCsvWriterFlattensNestedObjects(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewCsvOutputWriter(&output, NewDefaultTransformer(), []string{})
+
+	writeCsv(t, writer, `{"value":[{"Id":1,"Robot":{"Id":5,"Machine":{"Name":"m1"}},"Tags":["a","b"]}]}`)
+
+	expected := "Id,Robot.Id,Robot.Machine.Name,Tags\n1,5,m1,\"[\"\"a\"\",\"\"b\"\"]\"\n"
+	if output.String() != expected {
+		t.Errorf("Should flatten nested objects, but got: %v", output.String())
+	}
+}
+
+func TestCsvWriterUsesColumnsInProvidedOrder(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewCsvOutputWriter(&output, NewDefaultTransformer(), []string{"Robot.Id", "Name"})
+
+	writeCsv(t, writer, `[{"Id":1,"Name":"a","Robot":{"Id":5}}]`)
+
+	expected := "Robot.Id,Name\n5,a\n"
+	if output.String() != expected {
+		t.Errorf("Should only show the selected columns, but got: %v", output.String())
+	}
+}
+
+func TestCsvWriterAppliesTransformer(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewCsvOutputWriter(&output, NewJmesPathTransformer("value[?Id > `1`]"), []string{})
+
+	writeCsv(t, writer, `{"value":[{"Id":1},{"Id":2}]}`)
+
+	expected := "Id\n2\n"
+	if output.String() != expected {
+		t.Errorf("Should apply query before formatting, but got: %v", output.String())
+	}
+}
+
+func TestTsvWriterSeparatesFieldsByTab(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewTsvOutputWriter(&output, NewDefaultTransformer(), []string{})
+
+	writeCsv(t, writer, `[{"Id":1,"Name":"a b"}]`)
+
+	expected := "Id\tName\n1\ta b\n"
+	if output.String() != expected {
+		t.Errorf("Should separate fields by tab, but got: %v", output.String())
+	}
+}
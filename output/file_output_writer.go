@@ -0,0 +1,95 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The FileOutputWriter writes the CLI output to a file instead of standard output.
+//
+// It is used when the --output-file parameter is provided. JSON responses are
+// formatted using the configured output writer, all other responses are streamed
+// to disk without buffering them in memory. The file is written to a temporary
+// location first and renamed once the response has been written completely.
+// Error responses are not written to the file so that an existing file stays
+// untouched, the error is reported on standard error instead.
+type FileOutputWriter struct {
+	path      string
+	formatter func(io.Writer) OutputWriter
+}
+
+func (w FileOutputWriter) isJson(header map[string][]string) bool {
+	mediaType, _, err := mime.ParseMediaType(http.Header(header).Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func (w FileOutputWriter) isSuccess(response ResponseInfo) bool {
+	return response.StatusCode >= 200 && response.StatusCode < 300
+}
+
+// createTemp creates the temporary file with the same permissions as a
+// regularly created file (0644 minus umask) instead of the 0600 used by
+// os.CreateTemp so that the renamed output file is readable as usual.
+func (w FileOutputWriter) createTemp() (*os.File, error) {
+	dir := filepath.Dir(w.path)
+	prefix := "." + filepath.Base(w.path) + "."
+	for {
+		path := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+".tmp")
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		return file, err
+	}
+}
+
+func (w FileOutputWriter) write(file *os.File, response ResponseInfo) error {
+	if w.isJson(response.Header) {
+		return w.formatter(file).WriteResponse(response)
+	}
+	_, err := io.Copy(file, response.Body)
+	if err != nil {
+		return fmt.Errorf("Error writing output file '%s': %w", w.path, err)
+	}
+	return nil
+}
+
+func (w FileOutputWriter) WriteResponse(response ResponseInfo) error {
+	if !w.isSuccess(response) {
+		return nil
+	}
+	file, err := w.createTemp()
+	if err != nil {
+		return fmt.Errorf("Error creating output file '%s': %w", w.path, err)
+	}
+	tempPath := file.Name()
+	err = w.write(file, response)
+	closeErr := file.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("Error writing output file '%s': %w", w.path, closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	err = os.Rename(tempPath, w.path)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("Error writing output file '%s': %w", w.path, err)
+	}
+	return nil
+}
+
+func NewFileOutputWriter(path string, formatter func(io.Writer) OutputWriter) *FileOutputWriter {
+	return &FileOutputWriter{path, formatter}
+}
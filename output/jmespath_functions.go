@@ -0,0 +1,287 @@
+package output
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jmesPathDateFormat = "2006-01-02T15:04:05.000Z"
+
+var timeSpanPattern = regexp.MustCompile(`^(-)?(?:(\d+)\.)?(\d+):(\d+):(\d+)(?:\.(\d+))?$`)
+
+var durationUnits = map[string]float64{
+	"milliseconds": 0.001,
+	"seconds":      1,
+	"minutes":      60,
+	"hours":        3600,
+	"days":         86400,
+}
+
+const jmesPathNumber = "number"
+const jmesPathString = "string"
+
+// The jmesPathArgument defines the allowed types of a function argument.
+// Optional arguments can only be followed by other optional arguments.
+type jmesPathArgument struct {
+	Types    []string
+	Optional bool
+}
+
+// The jmesPathFunction is a custom function which can be called in addition
+// to the built-in JMESPath functions.
+type jmesPathFunction struct {
+	Arguments []jmesPathArgument
+	Handler   func(arguments []interface{}) (interface{}, error)
+}
+
+func (f jmesPathFunction) Call(name string, arguments []interface{}) (interface{}, error) {
+	required := 0
+	for _, argument := range f.Arguments {
+		if !argument.Optional {
+			required++
+		}
+	}
+	if len(arguments) < required || len(arguments) > len(f.Arguments) {
+		return nil, fmt.Errorf("Invalid arity for function %s, expected %d arguments but got %d", name, len(f.Arguments), len(arguments))
+	}
+	for index, argument := range arguments {
+		if !slices.Contains(f.Arguments[index].Types, jmesPathType(argument)) {
+			return nil, fmt.Errorf("Invalid argument for function %s, expected %s but got: %v", name, strings.Join(f.Arguments[index].Types, " or "), argument)
+		}
+	}
+	return f.Handler(arguments)
+}
+
+func jmesPathType(value interface{}) string {
+	switch value.(type) {
+	case float64:
+		return jmesPathNumber
+	case string:
+		return jmesPathString
+	}
+	return ""
+}
+
+// jmesPathFunctions contains the custom functions which are available in
+// addition to the standard JMESPath functions:
+//
+//	now()                         current time, e.g. "2024-01-25T12:49:18.907Z"
+//	to_datetime(value)            normalizes a timestamp or unix time to UTC
+//	date_diff(start, end, unit?)  time between two timestamps (default: seconds)
+//	duration(value, unit?)        converts "1h30m" or "01:30:00" to a number (default: seconds)
+//	regex_match(value, pattern)   checks if the value matches the regular expression
+//	base64_encode(value)          encodes the string using base64
+//	base64_decode(value)          decodes the base64 string
+//	to_upper(value)               converts the string to upper case
+//	to_lower(value)               converts the string to lower case
+//	split(value, separator)       splits the string into an array of strings
+//	parse_json(value)             parses the json string
+var jmesPathFunctions = map[string]jmesPathFunction{
+	"now": {
+		Handler: jmesPathNow,
+	},
+	"to_datetime": {
+		Arguments: []jmesPathArgument{{Types: []string{jmesPathString, jmesPathNumber}}},
+		Handler:   jmesPathToDatetime,
+	},
+	"date_diff": {
+		Arguments: []jmesPathArgument{
+			{Types: []string{jmesPathString, jmesPathNumber}},
+			{Types: []string{jmesPathString, jmesPathNumber}},
+			{Types: []string{jmesPathString}, Optional: true},
+		},
+		Handler: jmesPathDateDiff,
+	},
+	"duration": {
+		Arguments: []jmesPathArgument{
+			{Types: []string{jmesPathString}},
+			{Types: []string{jmesPathString}, Optional: true},
+		},
+		Handler: jmesPathDuration,
+	},
+	"regex_match": {
+		Arguments: []jmesPathArgument{
+			{Types: []string{jmesPathString}},
+			{Types: []string{jmesPathString}},
+		},
+		Handler: jmesPathRegexMatch,
+	},
+	"base64_encode": {
+		Arguments: []jmesPathArgument{{Types: []string{jmesPathString}}},
+		Handler:   jmesPathBase64Encode,
+	},
+	"base64_decode": {
+		Arguments: []jmesPathArgument{{Types: []string{jmesPathString}}},
+		Handler:   jmesPathBase64Decode,
+	},
+	"to_upper": {
+		Arguments: []jmesPathArgument{{Types: []string{jmesPathString}}},
+		Handler:   jmesPathToUpper,
+	},
+	"to_lower": {
+		Arguments: []jmesPathArgument{{Types: []string{jmesPathString}}},
+		Handler:   jmesPathToLower,
+	},
+	"split": {
+		Arguments: []jmesPathArgument{
+			{Types: []string{jmesPathString}},
+			{Types: []string{jmesPathString}},
+		},
+		Handler: jmesPathSplit,
+	},
+	"parse_json": {
+		Arguments: []jmesPathArgument{{Types: []string{jmesPathString}}},
+		Handler:   jmesPathParseJson,
+	},
+}
+
+func jmesPathNow(arguments []interface{}) (interface{}, error) {
+	return time.Now().UTC().Format(jmesPathDateFormat), nil
+}
+
+func jmesPathParseDatetime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case float64:
+		seconds, fraction := math.Modf(v)
+		return time.Unix(int64(seconds), int64(fraction*float64(time.Second))).UTC(), nil
+	case string:
+		for _, layout := range dateLayouts {
+			date, err := time.Parse(layout, v)
+			if err == nil {
+				return date.UTC(), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("Cannot convert '%v' to datetime", value)
+}
+
+func jmesPathToDatetime(arguments []interface{}) (interface{}, error) {
+	date, err := jmesPathParseDatetime(arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	return date.Format(jmesPathDateFormat), nil
+}
+
+func jmesPathUnit(arguments []interface{}, index int) (float64, error) {
+	if len(arguments) <= index {
+		return durationUnits["seconds"], nil
+	}
+	unit := arguments[index].(string)
+	factor, ok := durationUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("Invalid unit '%s', allowed values: milliseconds, seconds, minutes, hours, days", unit)
+	}
+	return factor, nil
+}
+
+func jmesPathDateDiff(arguments []interface{}) (interface{}, error) {
+	start, err := jmesPathParseDatetime(arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	end, err := jmesPathParseDatetime(arguments[1])
+	if err != nil {
+		return nil, err
+	}
+	unit, err := jmesPathUnit(arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	return end.Sub(start).Seconds() / unit, nil
+}
+
+// jmesPathParseDuration supports go durations like 1h30m and .NET TimeSpan
+// values like 1.02:30:00 which are returned by Orchestrator.
+func jmesPathParseDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return duration, nil
+	}
+	match := timeSpanPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("Cannot convert '%s' to duration", value)
+	}
+	days, _ := strconv.Atoi(match[2])
+	hours, _ := strconv.Atoi(match[3])
+	minutes, _ := strconv.Atoi(match[4])
+	seconds, _ := strconv.Atoi(match[5])
+	fraction, _ := strconv.ParseFloat("0."+match[6], 64)
+	duration = time.Duration(days)*24*time.Hour +
+		time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(fraction*float64(time.Second))
+	if match[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+func jmesPathDuration(arguments []interface{}) (interface{}, error) {
+	duration, err := jmesPathParseDuration(arguments[0].(string))
+	if err != nil {
+		return nil, err
+	}
+	unit, err := jmesPathUnit(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return duration.Seconds() / unit, nil
+}
+
+func jmesPathRegexMatch(arguments []interface{}) (interface{}, error) {
+	pattern, err := regexp.Compile(arguments[1].(string))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid regular expression '%s': %w", arguments[1], err)
+	}
+	return pattern.MatchString(arguments[0].(string)), nil
+}
+
+func jmesPathBase64Encode(arguments []interface{}) (interface{}, error) {
+	return base64.StdEncoding.EncodeToString([]byte(arguments[0].(string))), nil
+}
+
+func jmesPathBase64Decode(arguments []interface{}) (interface{}, error) {
+	value := arguments[0].(string)
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode base64 value '%s'", value)
+	}
+	return string(data), nil
+}
+
+func jmesPathToUpper(arguments []interface{}) (interface{}, error) {
+	return strings.ToUpper(arguments[0].(string)), nil
+}
+
+func jmesPathToLower(arguments []interface{}) (interface{}, error) {
+	return strings.ToLower(arguments[0].(string)), nil
+}
+
+func jmesPathSplit(arguments []interface{}) (interface{}, error) {
+	result := []interface{}{}
+	for _, value := range strings.Split(arguments[0].(string), arguments[1].(string)) {
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func jmesPathParseJson(arguments []interface{}) (interface{}, error) {
+	var data interface{}
+	err := json.Unmarshal([]byte(arguments[0].(string)), &data)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse json value: %w", err)
+	}
+	return data, nil
+}
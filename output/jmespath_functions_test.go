@@ -0,0 +1,125 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func executeQuery(t *testing.T, query string, data interface{}) interface{} {
+	result, err := NewJmesPathTransformer(query).Execute(data)
+	if err != nil {
+		t.Fatalf("Query '%s' failed: %v", query, err)
+	}
+	return result
+}
+
+func TestJmesPathNowReturnsCurrentTime(t *testing.T) {
+	result := executeQuery(t, "now()", nil)
+
+	now, err := time.Parse(time.RFC3339, result.(string))
+	if err != nil || time.Since(now) > time.Minute {
+		t.Errorf("Should return current time, but got: %v", result)
+	}
+}
+
+func TestJmesPathToDatetimeNormalizesTimestamps(t *testing.T) {
+	data := map[string]interface{}{
+		"offset": "2024-01-25T14:49:18.9+02:00",
+		"local":  "2024-01-25T12:49:18.907",
+		"unix":   1706186958.907,
+	}
+	result := executeQuery(t, "[to_datetime(offset), to_datetime(local), to_datetime(unix)]", data).([]interface{})
+
+	for _, value := range result {
+		if value != "2024-01-25T12:49:18.907Z" && value != "2024-01-25T12:49:18.900Z" {
+			t.Errorf("Should normalize timestamp, but got: %v", result)
+		}
+	}
+}
+
+func TestJmesPathDateDiffReturnsDifferenceInUnit(t *testing.T) {
+	data := map[string]interface{}{
+		"StartTime": "2024-01-25T12:00:00Z",
+		"EndTime":   "2024-01-25T13:30:00.000Z",
+	}
+	result := executeQuery(t, "[date_diff(StartTime, EndTime), date_diff(StartTime, EndTime, 'minutes'), date_diff(EndTime, StartTime, 'hours')]", data).([]interface{})
+
+	if result[0] != 5400.0 || result[1] != 90.0 || result[2] != -1.5 {
+		t.Errorf("Should return date difference, but got: %v", result)
+	}
+}
+
+func TestJmesPathDateDiffInvalidUnitReturnsError(t *testing.T) {
+	_, err := NewJmesPathTransformer("date_diff('2024-01-25', '2024-01-26', 'weeks')").Execute(nil)
+
+	if err == nil || !strings.Contains(err.Error(), "Invalid unit 'weeks'") {
+		t.Errorf("Should return invalid unit error, but got: %v", err)
+	}
+}
+
+func TestJmesPathDateDiffInvalidDateReturnsError(t *testing.T) {
+	_, err := NewJmesPathTransformer("date_diff('yesterday', '2024-01-26')").Execute(nil)
+
+	if err == nil || !strings.Contains(err.Error(), "Cannot convert 'yesterday' to datetime") {
+		t.Errorf("Should return invalid date error, but got: %v", err)
+	}
+}
+
+func TestJmesPathDurationSupportsGoDurationAndTimeSpan(t *testing.T) {
+	result := executeQuery(t, "[duration('1h30m'), duration('01:30:00', 'minutes'), duration('1.00:00:00.5'), duration('-00:00:10')]", map[string]interface{}{}).([]interface{})
+
+	if result[0] != 5400.0 || result[1] != 90.0 || result[2] != 86400.5 || result[3] != -10.0 {
+		t.Errorf("Should convert durations, but got: %v", result)
+	}
+}
+
+func TestJmesPathRegexMatch(t *testing.T) {
+	data := []interface{}{"Invoice-2024", "Receipt-2023", "invoice"}
+	result := executeQuery(t, "[?regex_match(@, '^Invoice-\\d+$')]", data).([]interface{})
+
+	if len(result) != 1 || result[0] != "Invoice-2024" {
+		t.Errorf("Should filter using regular expression, but got: %v", result)
+	}
+}
+
+func TestJmesPathRegexMatchInvalidPatternReturnsError(t *testing.T) {
+	_, err := NewJmesPathTransformer("regex_match('a', '[')").Execute(nil)
+
+	if err == nil || !strings.Contains(err.Error(), "Invalid regular expression '['") {
+		t.Errorf("Should return invalid pattern error, but got: %v", err)
+	}
+}
+
+func TestJmesPathBase64EncodeAndDecode(t *testing.T) {
+	result := executeQuery(t, "[base64_encode('hello'), base64_decode('aGVsbG8='), base64_decode('aGVsbG8')]", map[string]interface{}{}).([]interface{})
+
+	if result[0] != "aGVsbG8=" || result[1] != "hello" || result[2] != "hello" {
+		t.Errorf("Should encode and decode base64, but got: %v", result)
+	}
+}
+
+func TestJmesPathBase64DecodeInvalidValueReturnsError(t *testing.T) {
+	_, err := NewJmesPathTransformer("base64_decode('%%%')").Execute(nil)
+
+	if err == nil || !strings.Contains(err.Error(), "Cannot decode base64 value '%%%'") {
+		t.Errorf("Should return decode error, but got: %v", err)
+	}
+}
+
+func TestJmesPathStringFunctions(t *testing.T) {
+	result := executeQuery(t, "[to_upper('abc'), to_lower('ABC'), split('a,b', ',')]", map[string]interface{}{}).([]interface{})
+
+	if result[0] != "ABC" || result[1] != "abc" || len(result[2].([]interface{})) != 2 {
+		t.Errorf("Should transform strings, but got: %v", result)
+	}
+}
+
+func TestJmesPathParseJson(t *testing.T) {
+	data := map[string]interface{}{"Value": `{"user":"admin","port":8080}`}
+	result := executeQuery(t, "parse_json(Value).port", data)
+
+	if result != 8080.0 {
+		t.Errorf("Should parse json value, but got: %v", result)
+	}
+}
@@ -0,0 +1,499 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// jmesPathExpRefFunctions are the built-in functions which take an expression
+// reference like &Name as argument.
+var jmesPathExpRefFunctions = map[string]string{
+	"sort_by": "map(&v, sort_by([0], &k))",
+	"min_by":  "min_by([0], &k).v",
+	"max_by":  "max_by([0], &k).v",
+	"map":     "map(&k, [0])",
+}
+
+// jmesPathComparators maps the comparator values of the parsed expressions to
+// the operators.
+var jmesPathComparators = parseJmesPathComparators("==", "!=", "<", "<=", ">", ">=")
+
+// The jmesPathNode is the syntax tree of an expression which was parsed by the
+// go-jmespath library.
+type jmesPathNode struct {
+	nodeType int64
+	value    interface{}
+	children []jmesPathNode
+}
+
+// The jmesPathExpRef is the value of an expression reference like &Name.
+type jmesPathExpRef struct {
+	node jmesPathNode
+}
+
+// The jmesPathInterpreter evaluates JMESPath expressions which use the custom
+// functions in jmesPathFunctions.
+//
+// The go-jmespath library does not allow registering additional functions. The
+// expression is parsed by the library and the interpreter only evaluates the
+// syntax tree when it calls a custom function. The built-in functions are
+// delegated to the library so that they behave the same as in other queries.
+type jmesPathInterpreter struct {
+	evaluators map[int64]func(node jmesPathNode, value interface{}) (interface{}, error)
+}
+
+func (i *jmesPathInterpreter) Search(expression string, data interface{}) (interface{}, error) {
+	ast, err := jmespath.NewParser().Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	node := newJmesPathNode(reflect.ValueOf(ast))
+	if !i.callsCustomFunction(node) {
+		return jmespath.Search(expression, data)
+	}
+	return i.evaluate(node, data)
+}
+
+func (i *jmesPathInterpreter) callsCustomFunction(node jmesPathNode) bool {
+	if node.nodeType == int64(jmespath.ASTFunctionExpression) {
+		if _, found := jmesPathFunctions[node.value.(string)]; found {
+			return true
+		}
+	}
+	for _, child := range node.children {
+		if i.callsCustomFunction(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *jmesPathInterpreter) evaluate(node jmesPathNode, value interface{}) (interface{}, error) {
+	evaluator, found := i.evaluators[node.nodeType]
+	if !found {
+		return nil, fmt.Errorf("Unknown AST node: %d", node.nodeType)
+	}
+	return evaluator(node, value)
+}
+
+func (i *jmesPathInterpreter) evaluateComparator(node jmesPathNode, value interface{}) (interface{}, error) {
+	left, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, err
+	}
+	right, err := i.evaluate(node.children[1], value)
+	if err != nil {
+		return nil, err
+	}
+	operator := jmesPathComparators[node.value.(int64)]
+	switch operator {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	}
+	leftNumber, leftOk := left.(float64)
+	rightNumber, rightOk := right.(float64)
+	if !leftOk || !rightOk {
+		return nil, nil
+	}
+	switch operator {
+	case "<":
+		return leftNumber < rightNumber, nil
+	case "<=":
+		return leftNumber <= rightNumber, nil
+	case ">":
+		return leftNumber > rightNumber, nil
+	case ">=":
+		return leftNumber >= rightNumber, nil
+	}
+	return nil, nil
+}
+
+func (i *jmesPathInterpreter) evaluateExpRef(node jmesPathNode, value interface{}) (interface{}, error) {
+	return jmesPathExpRef{node.children[0]}, nil
+}
+
+func (i *jmesPathInterpreter) evaluateFunction(node jmesPathNode, value interface{}) (interface{}, error) {
+	arguments := []interface{}{}
+	for _, child := range node.children {
+		argument, err := i.evaluate(child, value)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	name := node.value.(string)
+	if function, found := jmesPathFunctions[name]; found {
+		return function.Call(name, arguments)
+	}
+	if expression, found := jmesPathExpRefFunctions[name]; found {
+		return i.callExpRefFunction(name, expression, arguments)
+	}
+	return i.callBuiltInFunction(name, arguments)
+}
+
+// callBuiltInFunction passes the evaluated arguments as array to the library,
+// e.g. length([0]) with the data [argument].
+func (i *jmesPathInterpreter) callBuiltInFunction(name string, arguments []interface{}) (interface{}, error) {
+	references := []string{}
+	for index, argument := range arguments {
+		if _, ok := argument.(jmesPathExpRef); ok {
+			references = append(references, "&@")
+			continue
+		}
+		references = append(references, fmt.Sprintf("[%d]", index))
+	}
+	expression := fmt.Sprintf("%s(%s)", name, strings.Join(references, ", "))
+	return jmespath.Search(expression, arguments)
+}
+
+// callExpRefFunction evaluates the expression reference for every element and
+// lets the library process the key/value pairs, e.g. sort_by([0], &k).
+func (i *jmesPathInterpreter) callExpRefFunction(name string, expression string, arguments []interface{}) (interface{}, error) {
+	arrayIndex, refIndex := 0, 1
+	if name == "map" {
+		arrayIndex, refIndex = 1, 0
+	}
+	if len(arguments) != 2 {
+		return i.callBuiltInFunction(name, arguments)
+	}
+	array, arrayOk := arguments[arrayIndex].([]interface{})
+	ref, refOk := arguments[refIndex].(jmesPathExpRef)
+	if !arrayOk || !refOk {
+		return i.callBuiltInFunction(name, arguments)
+	}
+	pairs := []interface{}{}
+	for _, element := range array {
+		key, err := i.evaluate(ref.node, element)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, map[string]interface{}{"k": key, "v": element})
+	}
+	return jmespath.Search(expression, []interface{}{pairs})
+}
+
+func (i *jmesPathInterpreter) evaluateField(node jmesPathNode, value interface{}) (interface{}, error) {
+	if obj, ok := value.(map[string]interface{}); ok {
+		return obj[node.value.(string)], nil
+	}
+	return nil, nil
+}
+
+func (i *jmesPathInterpreter) evaluateFilterProjection(node jmesPathNode, value interface{}) (interface{}, error) {
+	left, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, nil
+	}
+	array, ok := left.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	result := []interface{}{}
+	for _, element := range array {
+		matched, err := i.evaluate(node.children[2], element)
+		if err != nil {
+			return nil, err
+		}
+		if jmesPathIsFalse(matched) {
+			continue
+		}
+		current, err := i.evaluate(node.children[1], element)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil {
+			result = append(result, current)
+		}
+	}
+	return result, nil
+}
+
+func (i *jmesPathInterpreter) evaluateFlatten(node jmesPathNode, value interface{}) (interface{}, error) {
+	left, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, nil
+	}
+	array, ok := left.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	result := []interface{}{}
+	for _, element := range array {
+		if nested, ok := element.([]interface{}); ok {
+			result = append(result, nested...)
+		} else {
+			result = append(result, element)
+		}
+	}
+	return result, nil
+}
+
+func (i *jmesPathInterpreter) evaluateCurrent(node jmesPathNode, value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (i *jmesPathInterpreter) evaluateIndex(node jmesPathNode, value interface{}) (interface{}, error) {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	index := int(node.value.(int64))
+	if index < 0 {
+		index += len(array)
+	}
+	if index < 0 || index >= len(array) {
+		return nil, nil
+	}
+	return array[index], nil
+}
+
+func (i *jmesPathInterpreter) evaluateFirstChild(node jmesPathNode, value interface{}) (interface{}, error) {
+	return i.evaluate(node.children[0], value)
+}
+
+func (i *jmesPathInterpreter) evaluateLiteral(node jmesPathNode, value interface{}) (interface{}, error) {
+	return node.value, nil
+}
+
+func (i *jmesPathInterpreter) evaluateMultiSelectHash(node jmesPathNode, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	result := map[string]interface{}{}
+	for _, child := range node.children {
+		current, err := i.evaluate(child, value)
+		if err != nil {
+			return nil, err
+		}
+		result[child.value.(string)] = current
+	}
+	return result, nil
+}
+
+func (i *jmesPathInterpreter) evaluateMultiSelectList(node jmesPathNode, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	result := []interface{}{}
+	for _, child := range node.children {
+		current, err := i.evaluate(child, value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, current)
+	}
+	return result, nil
+}
+
+func (i *jmesPathInterpreter) evaluateOr(node jmesPathNode, value interface{}) (interface{}, error) {
+	matched, err := i.evaluate(node.children[0], value)
+	if err != nil || !jmesPathIsFalse(matched) {
+		return matched, err
+	}
+	return i.evaluate(node.children[1], value)
+}
+
+func (i *jmesPathInterpreter) evaluateAnd(node jmesPathNode, value interface{}) (interface{}, error) {
+	matched, err := i.evaluate(node.children[0], value)
+	if err != nil || jmesPathIsFalse(matched) {
+		return matched, err
+	}
+	return i.evaluate(node.children[1], value)
+}
+
+func (i *jmesPathInterpreter) evaluateNot(node jmesPathNode, value interface{}) (interface{}, error) {
+	matched, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, err
+	}
+	return jmesPathIsFalse(matched), nil
+}
+
+func (i *jmesPathInterpreter) evaluatePipe(node jmesPathNode, value interface{}) (interface{}, error) {
+	result := value
+	for _, child := range node.children {
+		var err error
+		result, err = i.evaluate(child, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (i *jmesPathInterpreter) evaluateProjection(node jmesPathNode, value interface{}) (interface{}, error) {
+	left, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, err
+	}
+	array, ok := left.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	return i.project(node.children[1], array)
+}
+
+func (i *jmesPathInterpreter) evaluateValueProjection(node jmesPathNode, value interface{}) (interface{}, error) {
+	left, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, nil
+	}
+	obj, ok := left.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	values := []interface{}{}
+	for _, element := range obj {
+		values = append(values, element)
+	}
+	return i.project(node.children[1], values)
+}
+
+func (i *jmesPathInterpreter) project(node jmesPathNode, array []interface{}) (interface{}, error) {
+	result := []interface{}{}
+	for _, element := range array {
+		current, err := i.evaluate(node, element)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil {
+			result = append(result, current)
+		}
+	}
+	return result, nil
+}
+
+func (i *jmesPathInterpreter) evaluateSubexpression(node jmesPathNode, value interface{}) (interface{}, error) {
+	left, err := i.evaluate(node.children[0], value)
+	if err != nil {
+		return nil, err
+	}
+	return i.evaluate(node.children[1], left)
+}
+
+// evaluateSlice lets the library slice the array, e.g. [1:5:2].
+func (i *jmesPathInterpreter) evaluateSlice(node jmesPathNode, value interface{}) (interface{}, error) {
+	if _, ok := value.([]interface{}); !ok {
+		return nil, nil
+	}
+	parts := []string{}
+	for _, part := range node.value.([]interface{}) {
+		if part == nil {
+			parts = append(parts, "")
+		} else {
+			parts = append(parts, fmt.Sprintf("%d", part))
+		}
+	}
+	return jmespath.Search("["+strings.Join(parts, ":")+"]", value)
+}
+
+func jmesPathIsFalse(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// newJmesPathNode reads the syntax tree created by the go-jmespath parser.
+// The fields of the library's ASTNode are not exported and can only be read
+// using reflection.
+func newJmesPathNode(node reflect.Value) jmesPathNode {
+	result := jmesPathNode{
+		nodeType: node.FieldByName("nodeType").Int(),
+		value:    jmesPathNodeValue(node.FieldByName("value")),
+	}
+	children := node.FieldByName("children")
+	for index := range children.Len() {
+		result.children = append(result.children, newJmesPathNode(children.Index(index)))
+	}
+	return result
+}
+
+func jmesPathNodeValue(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		return jmesPathNodeValue(value.Elem())
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Float64:
+		return value.Float()
+	case reflect.Int:
+		return value.Int()
+	case reflect.Slice:
+		result := []interface{}{}
+		for index := range value.Len() {
+			result = append(result, jmesPathNodeValue(value.Index(index)))
+		}
+		return result
+	case reflect.Map:
+		result := map[string]interface{}{}
+		iterator := value.MapRange()
+		for iterator.Next() {
+			result[iterator.Key().String()] = jmesPathNodeValue(iterator.Value())
+		}
+		return result
+	}
+	return nil
+}
+
+func parseJmesPathComparators(operators ...string) map[int64]string {
+	result := map[int64]string{}
+	for _, operator := range operators {
+		ast, err := jmespath.NewParser().Parse("a " + operator + " b")
+		if err != nil {
+			panic(errors.New("Cannot parse JMESPath comparator " + operator))
+		}
+		node := newJmesPathNode(reflect.ValueOf(ast))
+		result[node.value.(int64)] = operator
+	}
+	return result
+}
+
+func newJmesPathInterpreter() *jmesPathInterpreter {
+	interpreter := jmesPathInterpreter{}
+	interpreter.evaluators = map[int64]func(node jmesPathNode, value interface{}) (interface{}, error){
+		int64(jmespath.ASTComparator):         interpreter.evaluateComparator,
+		int64(jmespath.ASTCurrentNode):        interpreter.evaluateCurrent,
+		int64(jmespath.ASTExpRef):             interpreter.evaluateExpRef,
+		int64(jmespath.ASTFunctionExpression): interpreter.evaluateFunction,
+		int64(jmespath.ASTField):              interpreter.evaluateField,
+		int64(jmespath.ASTFilterProjection):   interpreter.evaluateFilterProjection,
+		int64(jmespath.ASTFlatten):            interpreter.evaluateFlatten,
+		int64(jmespath.ASTIdentity):           interpreter.evaluateCurrent,
+		int64(jmespath.ASTIndex):              interpreter.evaluateIndex,
+		int64(jmespath.ASTIndexExpression):    interpreter.evaluateSubexpression,
+		int64(jmespath.ASTKeyValPair):         interpreter.evaluateFirstChild,
+		int64(jmespath.ASTLiteral):            interpreter.evaluateLiteral,
+		int64(jmespath.ASTMultiSelectHash):    interpreter.evaluateMultiSelectHash,
+		int64(jmespath.ASTMultiSelectList):    interpreter.evaluateMultiSelectList,
+		int64(jmespath.ASTOrExpression):       interpreter.evaluateOr,
+		int64(jmespath.ASTAndExpression):      interpreter.evaluateAnd,
+		int64(jmespath.ASTNotExpression):      interpreter.evaluateNot,
+		int64(jmespath.ASTPipe):               interpreter.evaluatePipe,
+		int64(jmespath.ASTProjection):         interpreter.evaluateProjection,
+		int64(jmespath.ASTSubexpression):      interpreter.evaluateSubexpression,
+		int64(jmespath.ASTSlice):              interpreter.evaluateSlice,
+		int64(jmespath.ASTValueProjection):    interpreter.evaluateValueProjection,
+	}
+	return &interpreter
+}
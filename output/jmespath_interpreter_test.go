@@ -0,0 +1,93 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func searchJson(t *testing.T, query string, document string) interface{} {
+	var data interface{}
+	err := json.Unmarshal([]byte(document), &data)
+	if err != nil {
+		t.Fatalf("Invalid test document: %v", err)
+	}
+	result, err := newJmesPathInterpreter().Search(query, data)
+	if err != nil {
+		t.Fatalf("Query '%s' failed: %v", query, err)
+	}
+	return result
+}
+
+func assertJson(t *testing.T, expected string, actual interface{}) {
+	var expectedValue interface{}
+	_ = json.Unmarshal([]byte(expected), &expectedValue)
+	if !reflect.DeepEqual(expectedValue, actual) {
+		data, _ := json.Marshal(actual)
+		t.Errorf("Expected %s, but got: %s", expected, string(data))
+	}
+}
+
+func TestJmesPathInterpreterFilterProjectionWithCustomFunction(t *testing.T) {
+	document := `{"value":[{"Name":"Invoice-1","Id":1},{"Name":"Receipt-2","Id":2},{"Name":"Invoice-3","Id":3}]}`
+
+	result := searchJson(t, "value[?regex_match(Name, '^Invoice')].{id: Id, name: to_lower(Name)}", document)
+
+	assertJson(t, `[{"id":1,"name":"invoice-1"},{"id":3,"name":"invoice-3"}]`, result)
+}
+
+func TestJmesPathInterpreterDelegatesBuiltInFunctions(t *testing.T) {
+	document := `{"Tags":["a","b"],"Name":"Job"}`
+
+	result := searchJson(t, "[length(Tags), contains(Tags, 'b'), join('-', Tags), to_upper(Name)]", document)
+
+	assertJson(t, `[2,true,"a-b","JOB"]`, result)
+}
+
+func TestJmesPathInterpreterExpressionReferenceFunctions(t *testing.T) {
+	document := `[{"Name":"b","Time":"01:00:00"},{"Name":"a","Time":"00:30:00"},{"Name":"c","Time":"02:00:00"}]`
+
+	result := searchJson(t, "[sort_by(@, &duration(Time))[*].Name, max_by(@, &duration(Time)).Name, min_by(@, &duration(Time)).Name, map(&to_upper(Name), @)]", document)
+
+	assertJson(t, `[["a","b","c"],"c","a",["B","A","C"]]`, result)
+}
+
+func TestJmesPathInterpreterPipesSlicesAndComparators(t *testing.T) {
+	document := `{"value":[{"Id":1,"Time":"00:01:00"},{"Id":2,"Time":"00:10:00"},{"Id":3,"Time":"01:00:00"},{"Id":4,"Time":"00:00:10"}]}`
+
+	result := searchJson(t, "value[1:].{id: Id, minutes: duration(Time, 'minutes')} | [?minutes >= `10` && minutes != `60`].id", document)
+
+	assertJson(t, `[2]`, result)
+}
+
+func TestJmesPathInterpreterFlattenAndValueProjection(t *testing.T) {
+	document := `{"a":{"Names":["x","y"]},"b":{"Names":["z"]}}`
+
+	result := searchJson(t, "sort(*.Names[].to_upper(@)[])", document)
+
+	assertJson(t, `["X","Y","Z"]`, result)
+}
+
+func TestJmesPathInterpreterValidatesCustomFunctionArguments(t *testing.T) {
+	_, err := newJmesPathInterpreter().Search("to_upper('a', 'b')", nil)
+	if err == nil || err.Error() != "Invalid arity for function to_upper, expected 1 arguments but got 2" {
+		t.Errorf("Expected arity error, but got: %v", err)
+	}
+
+	_, err = newJmesPathInterpreter().Search("to_upper(`1`)", nil)
+	if err == nil || err.Error() != "Invalid argument for function to_upper, expected string but got: 1" {
+		t.Errorf("Expected invalid argument error, but got: %v", err)
+	}
+}
+
+func TestJmesPathInterpreterReturnsLibraryErrors(t *testing.T) {
+	_, err := newJmesPathInterpreter().Search("unknown(to_upper('a'))", nil)
+	if err == nil || err.Error() != "unknown function: unknown" {
+		t.Errorf("Expected unknown function error, but got: %v", err)
+	}
+
+	_, err = newJmesPathInterpreter().Search("to_upper('a') 2", nil)
+	if err == nil || err.Error() != "SyntaxError: Unexpected token at the end of the expression: tNumber" {
+		t.Errorf("Expected syntax error, but got: %v", err)
+	}
+}
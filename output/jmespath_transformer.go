@@ -1,10 +1,6 @@
 package output
 
-import (
-	"fmt"
-
-	"github.com/jmespath/go-jmespath"
-)
+import "fmt"
 
 // The JmesPathTransformer uses the JMESPath query language to transform the executor output.
 //
@@ -17,13 +13,16 @@ import (
 //
 // => "521b4edc-ad6f-4301-909e-f96a401e1fed"
 //
+// The query supports the standard JMESPath functions and the custom functions
+// defined in jmesPathFunctions, e.g. to work with dates and durations.
+//
 // See https://jmespath.org for more information.
 type JmesPathTransformer struct {
 	query string
 }
 
 func (t JmesPathTransformer) Execute(data interface{}) (interface{}, error) {
-	result, err := jmespath.Search(t.query, data)
+	result, err := newJmesPathInterpreter().Search(t.query, data)
 	if err != nil {
 		return nil, fmt.Errorf("Error in query: %w", err)
 	}
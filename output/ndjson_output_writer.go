@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// The NdJsonOutputWriter formats the CLI output as newline-delimited json
+// meaning every element is printed as compact json on a separate line.
+//
+// It is used when the --output ndjson parameter is provided. Arrays and the
+// OData "value" arrays are unwrapped so that every item is printed on its own
+// line. The writer supports streaming: paginated results and batch rows are
+// written as soon as they are available.
+// Example:
+// {"Id":1,"Name":"foo"}
+// {"Id":2,"Name":"bar"}
+type NdJsonOutputWriter struct {
+	output      io.Writer
+	transformer Transformer
+	mutex       sync.Mutex
+}
+
+func (w *NdJsonOutputWriter) Streaming() bool {
+	return true
+}
+
+func (w *NdJsonOutputWriter) format(value interface{}) ([]byte, error) {
+	items, ok := unwrapODataValue(value).([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	result := bytes.Buffer{}
+	for _, item := range items {
+		line, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		result.Write(line)
+		result.WriteString(ObjectSeparator)
+	}
+	return result.Bytes(), nil
+}
+
+func (w *NdJsonOutputWriter) writeBody(body []byte) error {
+	var data interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		w.write(body)
+		return nil
+	}
+
+	transformedResult, err := w.transformer.Execute(data)
+	if err != nil {
+		return err
+	}
+	result, err := w.format(transformedResult)
+	if err != nil {
+		return err
+	}
+	w.write(result)
+	return nil
+}
+
+func (w *NdJsonOutputWriter) write(data []byte) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, _ = w.output.Write(data)
+}
+
+func (w *NdJsonOutputWriter) WriteResponse(response ResponseInfo) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 && response.StatusCode >= 400 {
+		w.write(fmt.Appendf(nil, "%s %s\n", response.Protocol, response.Status))
+		return nil
+	}
+	return w.writeBody(body)
+}
+
+func NewNdJsonOutputWriter(output io.Writer, transformer Transformer) *NdJsonOutputWriter {
+	return &NdJsonOutputWriter{output: output, transformer: transformer}
+}
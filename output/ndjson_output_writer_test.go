@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestNdJsonWriterOutputsErrorStatusWhenResponseIsFailure(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewNdJsonOutputWriter(&output, NewDefaultTransformer())
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusBadRequest, "400 BadRequest", "HTTP/1.1", map[string][]string{}, bytes.NewReader([]byte{})))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "HTTP/1.1 400 BadRequest\n" {
+		t.Errorf("Should show HTTP error status, but got: %v", output.String())
+	}
+}
+
+func TestNdJsonWriterOutputsObjectAsCompactLine(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewNdJsonOutputWriter(&output, NewDefaultTransformer())
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(`{ "b": { "c": 1 }, "a": "foo" }`)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != `{"a":"foo","b":{"c":1}}`+"\n" {
+		t.Errorf("Should show compact json line, but got: %v", output.String())
+	}
+}
+
+func TestNdJsonWriterUnwrapsODataValue(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewNdJsonOutputWriter(&output, NewDefaultTransformer())
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(`{"@odata.count":2,"value":[{"Id":1},{"Id":2}]}`)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "{\"Id\":1}\n{\"Id\":2}\n" {
+		t.Errorf("Should show one line per item, but got: %v", output.String())
+	}
+}
+
+func TestNdJsonWriterAppliesTransformer(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewNdJsonOutputWriter(&output, NewJmesPathTransformer("value[].Id"))
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(`{"value":[{"Id":1},{"Id":2}]}`)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "1\n2\n" {
+		t.Errorf("Should apply query before formatting, but got: %v", output.String())
+	}
+}
+
+func TestNdJsonWriterIsStreaming(t *testing.T) {
+	writer := NewNdJsonOutputWriter(&bytes.Buffer{}, NewDefaultTransformer())
+
+	if !IsStreaming(writer) {
+		t.Errorf("Should support streaming")
+	}
+	if IsStreaming(NewJsonOutputWriter(&bytes.Buffer{}, NewDefaultTransformer())) {
+		t.Errorf("Json writer should not support streaming")
+	}
+}
@@ -0,0 +1,32 @@
+package output
+
+import "math"
+
+// maxSafeInteger is the largest integer which can be represented exactly
+// as float64.
+const maxSafeInteger = 1 << 53
+
+// normalizeNumbers converts whole numbers to integers so that they are not rendered
+// as floating-point numbers like 1.0 or 1.2e+13.
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= maxSafeInteger {
+			return int64(v)
+		}
+		return v
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			result[key] = normalizeNumbers(value)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, value := range v {
+			result[i] = normalizeNumbers(value)
+		}
+		return result
+	}
+	return value
+}
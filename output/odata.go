@@ -0,0 +1,137 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// unwrapODataValue returns the array nested under the "value" property of
+// OData responses so that the individual entities can be formatted as rows.
+// Any other value is returned unchanged.
+func unwrapODataValue(value interface{}) interface{} {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	if array, ok := obj["value"].([]interface{}); ok {
+		return array
+	}
+	return value
+}
+
+// collectColumns returns the sorted keys of all rows. Nested objects and arrays
+// are skipped unless includeNested is set.
+func collectColumns(rows []map[string]interface{}, includeNested bool) []string {
+	uniqueKeys := map[string]bool{}
+	for _, row := range rows {
+		for key, value := range row {
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				if !includeNested {
+					continue
+				}
+			}
+			uniqueKeys[key] = true
+		}
+	}
+	keys := []string{}
+	for key := range uniqueKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupColumn returns the value of the column and falls back to a case
+// insensitive match so that --columns does not depend on the exact casing.
+func lookupColumn(row map[string]interface{}, column string) interface{} {
+	if value, ok := row[column]; ok {
+		return value
+	}
+	for key, value := range row {
+		if strings.EqualFold(key, column) {
+			return value
+		}
+	}
+	return nil
+}
+
+// formatColumnValue converts a single value into its cell representation.
+// Nested objects and arrays are serialized as json.
+func formatColumnValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func formatObjectRows(rows []map[string]interface{}, columns []string, format func(interface{}) string) [][]string {
+	result := [][]string{}
+	for _, row := range rows {
+		values := []string{}
+		for _, column := range columns {
+			values = append(values, format(lookupColumn(row, column)))
+		}
+		result = append(result, values)
+	}
+	return result
+}
+
+// formatValueRows creates a row for every item of an array which does not
+// contain objects. Nested arrays are spread across multiple columns.
+func formatValueRows(array []interface{}, format func(interface{}) string) [][]string {
+	result := [][]string{}
+	for _, item := range array {
+		values := []string{}
+		if row, ok := item.([]interface{}); ok {
+			for _, value := range row {
+				values = append(values, format(value))
+			}
+		} else {
+			values = append(values, format(item))
+		}
+		result = append(result, values)
+	}
+	return result
+}
+
+// writeRowsResponse transforms the json response body and passes the result
+// to the write function. Bodies which are not json are written unchanged and
+// empty error responses show the status line instead.
+func writeRowsResponse(output io.Writer, transformer Transformer, response ResponseInfo, write func(interface{}) error) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 && response.StatusCode >= 400 {
+		_, _ = fmt.Fprintf(output, "%s %s\n", response.Protocol, response.Status)
+		return nil
+	}
+
+	var data interface{}
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		_, _ = fmt.Fprint(output, string(body))
+		return nil
+	}
+	transformedResult, err := transformer.Execute(data)
+	if err != nil {
+		return err
+	}
+	return write(transformedResult)
+}
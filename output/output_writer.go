@@ -1,6 +1,6 @@
 // Package output formats and prints the response on standard output.
 //
-// - Supports JSON and text output
+// - Supports JSON, NDJSON, YAML, text, table, CSV and template output
 // - Provides mechanism to transform output using JMESPath queries
 package output
 
@@ -8,3 +8,18 @@ package output
 type OutputWriter interface {
 	WriteResponse(response ResponseInfo) error
 }
+
+// The StreamingOutputWriter is implemented by output writers which format every
+// response on its own. Paginated and batch results are written as soon as they
+// are available instead of being merged into a single response.
+type StreamingOutputWriter interface {
+	OutputWriter
+	Streaming() bool
+}
+
+// IsStreaming returns true in case the writer prints partial results as soon
+// as they are available.
+func IsStreaming(writer OutputWriter) bool {
+	streamingWriter, ok := writer.(StreamingOutputWriter)
+	return ok && streamingWriter.Streaming()
+}
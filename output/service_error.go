@@ -0,0 +1,159 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// The ServiceError contains the details of an unsuccessful service response
+// which are returned by the UiPath services in the response body.
+//
+// The services use slightly different error envelopes: Orchestrator returns
+// the message, errorCode and traceId properties, Identity returns problem
+// details or OAuth errors and Document Understanding nests the details in an
+// error object.
+// Example:
+// {"message":"You are not authorized!","errorCode":0,"traceId":"00-abc-def-00"}
+type ServiceError struct {
+	ErrorCode interface{} `json:"errorCode,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	TraceId   string      `json:"traceId,omitempty"`
+}
+
+func (e ServiceError) String() string {
+	details := []string{}
+	if e.ErrorCode != nil {
+		details = append(details, fmt.Sprintf("error code: %v", e.ErrorCode))
+	}
+	if e.TraceId != "" {
+		details = append(details, "trace id: "+e.TraceId)
+	}
+	if len(details) == 0 {
+		return e.Message
+	}
+	if e.Message == "" {
+		return strings.Join(details, ", ")
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(details, ", "))
+}
+
+func serviceErrorValue(data map[string]interface{}, names ...string) interface{} {
+	for _, name := range names {
+		for key, value := range data {
+			if strings.EqualFold(key, name) && value != nil && value != "" {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+func serviceErrorString(data map[string]interface{}, names ...string) string {
+	value, ok := serviceErrorValue(data, names...).(string)
+	if !ok {
+		return ""
+	}
+	return value
+}
+
+// serviceValidationErrors formats the validation errors of the problem
+// details returned by Identity, e.g. {"errors":{"Name":["Name is required."]}}
+func serviceValidationErrors(data map[string]interface{}) string {
+	errors, ok := serviceErrorValue(data, "errors").(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	fields := []string{}
+	for field := range errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	result := []string{}
+	for _, field := range fields {
+		messages, ok := errors[field].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, message := range messages {
+			result = append(result, fmt.Sprintf("%s: %v", field, message))
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+// ParseServiceError extracts the error code, message and trace id from the
+// response body. It returns nil in case the body does not contain any of them.
+func ParseServiceError(body []byte) *ServiceError {
+	var data map[string]interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return nil
+	}
+	errorCode := serviceErrorValue(data, "errorCode", "code")
+	message := serviceErrorString(data, "message", "error_description", "detail", "title")
+	traceId := serviceErrorString(data, "traceId")
+
+	switch nested := serviceErrorValue(data, "error").(type) {
+	case map[string]interface{}:
+		if errorCode == nil {
+			errorCode = serviceErrorValue(nested, "code", "errorCode")
+		}
+		if message == "" {
+			message = serviceErrorString(nested, "message", "details")
+		}
+	case string:
+		if errorCode == nil {
+			errorCode = nested
+		}
+	}
+	if validationErrors := serviceValidationErrors(data); validationErrors != "" {
+		message = strings.TrimSpace(message + " " + validationErrors)
+	}
+
+	if errorCode == nil && message == "" && traceId == "" {
+		return nil
+	}
+	return &ServiceError{errorCode, message, traceId}
+}
+
+// FormatServiceError returns a concise error message for the unsuccessful
+// service response containing the error message, code and trace id. The raw
+// body is included in case it does not contain any known error details.
+func FormatServiceError(service string, statusCode int, body []byte) string {
+	serviceError := ParseServiceError(body)
+	if serviceError == nil {
+		return fmt.Sprintf("%s returned status code '%v' and body '%v'", service, statusCode, string(body))
+	}
+	return fmt.Sprintf("%s returned status code '%v': %s", service, statusCode, serviceError.String())
+}
+
+// ServiceErrorHint returns an actionable hint for authorization failures which
+// lists the OAuth scopes required by the operation. Every scope group is
+// required and can be satisfied by one of its scopes.
+func ServiceErrorHint(statusCode int, scopes [][]string) string {
+	if statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden {
+		return ""
+	}
+	if len(scopes) == 0 {
+		return ""
+	}
+	groups := []string{}
+	for _, group := range scopes {
+		groups = append(groups, formatScopeGroup(group, len(scopes) > 1))
+	}
+	return fmt.Sprintf("Hint: Make sure your credentials have been granted the OAuth scopes for this operation: %s", strings.Join(groups, " and "))
+}
+
+func formatScopeGroup(group []string, enclose bool) string {
+	if len(group) == 1 {
+		return group[0]
+	}
+	result := "one of: " + strings.Join(group, ", ")
+	if enclose {
+		return "(" + result + ")"
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestParseServiceErrorOrchestrator(t *testing.T) {
+	body := `{"message":"Folder does not exist or the user does not have access to the folder.","errorCode":1100,"traceId":"00-7b0a3b0c-00"}`
+
+	result := ParseServiceError([]byte(body))
+
+	if result.ErrorCode != 1100.0 {
+		t.Errorf("Expected error code 1100, but got: %v", result.ErrorCode)
+	}
+	if result.Message != "Folder does not exist or the user does not have access to the folder." {
+		t.Errorf("Expected message, but got: %v", result.Message)
+	}
+	if result.TraceId != "00-7b0a3b0c-00" {
+		t.Errorf("Expected trace id, but got: %v", result.TraceId)
+	}
+}
+
+func TestParseServiceErrorCodeProperty(t *testing.T) {
+	body := `{"code":"ProjectNotFound","Message":"The project does not exist"}`
+
+	result := ParseServiceError([]byte(body))
+
+	if result.ErrorCode != "ProjectNotFound" {
+		t.Errorf("Expected error code ProjectNotFound, but got: %v", result.ErrorCode)
+	}
+	if result.Message != "The project does not exist" {
+		t.Errorf("Expected message, but got: %v", result.Message)
+	}
+}
+
+func TestParseServiceErrorReturnsNilForUnknownBody(t *testing.T) {
+	bodies := []string{"", "Internal Server Error", `["error"]`, `{"id":1}`}
+	for _, body := range bodies {
+		result := ParseServiceError([]byte(body))
+		if result != nil {
+			t.Errorf("Expected no service error for body %s, but got: %v", body, result)
+		}
+	}
+}
+
+func TestParseServiceErrorOrchestratorNestedError(t *testing.T) {
+	body := `{"result":null,"success":false,"message":null,"error":{"code":0,"message":"Current user did not login to the application!","details":null}}`
+
+	result := ParseServiceError([]byte(body))
+
+	if result.ErrorCode != 0.0 {
+		t.Errorf("Expected error code 0, but got: %v", result.ErrorCode)
+	}
+	if result.Message != "Current user did not login to the application!" {
+		t.Errorf("Expected nested message, but got: %v", result.Message)
+	}
+}
+
+func TestParseServiceErrorIdentityOAuthError(t *testing.T) {
+	body := `{"error":"invalid_scope","error_description":"The scope OR.Unknown is not allowed."}`
+
+	result := ParseServiceError([]byte(body))
+
+	if result.ErrorCode != "invalid_scope" {
+		t.Errorf("Expected error code invalid_scope, but got: %v", result.ErrorCode)
+	}
+	if result.Message != "The scope OR.Unknown is not allowed." {
+		t.Errorf("Expected error description, but got: %v", result.Message)
+	}
+}
+
+func TestParseServiceErrorIdentityProblemDetails(t *testing.T) {
+	body := `{"title":"One or more validation errors occurred.","status":400,"traceId":"00-9f8e7d6c-00","errors":{"Name":["The Name field is required."],"Email":["The Email field is invalid."]}}`
+
+	result := ParseServiceError([]byte(body))
+
+	expected := "One or more validation errors occurred. Email: The Email field is invalid. Name: The Name field is required."
+	if result.Message != expected {
+		t.Errorf("Expected message %v, but got: %v", expected, result.Message)
+	}
+	if result.TraceId != "00-9f8e7d6c-00" {
+		t.Errorf("Expected trace id, but got: %v", result.TraceId)
+	}
+}
+
+func TestParseServiceErrorDocumentUnderstanding(t *testing.T) {
+	body := `{"status":"Failed","error":{"code":"[DocumentNotFound]","message":"Document not found","severity":"Error","parameters":[]}}`
+
+	result := ParseServiceError([]byte(body))
+
+	if result.ErrorCode != "[DocumentNotFound]" {
+		t.Errorf("Expected error code [DocumentNotFound], but got: %v", result.ErrorCode)
+	}
+	if result.Message != "Document not found" {
+		t.Errorf("Expected message, but got: %v", result.Message)
+	}
+}
+
+func TestFormatServiceErrorShowsMessageErrorCodeAndTraceId(t *testing.T) {
+	body := `{"message":"You are not authorized!","errorCode":0,"traceId":"00-d1d2f4c4-00"}`
+
+	result := FormatServiceError("Service", 403, []byte(body))
+
+	expected := "Service returned status code '403': You are not authorized! (error code: 0, trace id: 00-d1d2f4c4-00)"
+	if result != expected {
+		t.Errorf("Expected %v, but got: %v", expected, result)
+	}
+}
+
+func TestFormatServiceErrorShowsRawBodyForUnknownEnvelope(t *testing.T) {
+	result := FormatServiceError("Service", 502, []byte("Bad Gateway"))
+
+	expected := "Service returned status code '502' and body 'Bad Gateway'"
+	if result != expected {
+		t.Errorf("Expected %v, but got: %v", expected, result)
+	}
+}
+
+func TestServiceErrorHintListsAlternativeScopes(t *testing.T) {
+	result := ServiceErrorHint(403, [][]string{{"OR.Folders", "OR.Folders.Read"}})
+
+	expected := "Hint: Make sure your credentials have been granted the OAuth scopes for this operation: one of: OR.Folders, OR.Folders.Read"
+	if result != expected {
+		t.Errorf("Expected %v, but got: %v", expected, result)
+	}
+}
+
+func TestServiceErrorHintListsRequiredScopeGroups(t *testing.T) {
+	result := ServiceErrorHint(401, [][]string{{"OR.Tasks", "OR.Tasks.Read"}, {"Du.Digitization.Api"}})
+
+	expected := "Hint: Make sure your credentials have been granted the OAuth scopes for this operation: (one of: OR.Tasks, OR.Tasks.Read) and Du.Digitization.Api"
+	if result != expected {
+		t.Errorf("Expected %v, but got: %v", expected, result)
+	}
+}
+
+func TestServiceErrorHintEmptyForOtherStatusCodesOrNoScopes(t *testing.T) {
+	if result := ServiceErrorHint(404, [][]string{{"OR.Folders"}}); result != "" {
+		t.Errorf("Expected no hint for 404, but got: %v", result)
+	}
+	if result := ServiceErrorHint(401, [][]string{}); result != "" {
+		t.Errorf("Expected no hint without scopes, but got: %v", result)
+	}
+}
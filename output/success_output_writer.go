@@ -0,0 +1,26 @@
+package output
+
+// The SuccessOutputWriter only forwards successful responses to the
+// underlying output writer.
+//
+// It is used with --error-format json so that error responses are only
+// reported as structured errors on standard error and do not end up on
+// standard output.
+type SuccessOutputWriter struct {
+	writer OutputWriter
+}
+
+func (w SuccessOutputWriter) WriteResponse(response ResponseInfo) error {
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil
+	}
+	return w.writer.WriteResponse(response)
+}
+
+func (w SuccessOutputWriter) Streaming() bool {
+	return IsStreaming(w.writer)
+}
+
+func NewSuccessOutputWriter(writer OutputWriter) *SuccessOutputWriter {
+	return &SuccessOutputWriter{writer}
+}
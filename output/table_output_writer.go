@@ -0,0 +1,162 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const tableColumnSeparator = "  "
+const tableMinColumnWidth = 5
+const tableEllipsis = "..."
+
+// The TableOutputWriter formats the CLI output as a table with aligned columns
+// and a header row.
+//
+// It is used when the --output table parameter is provided. The columns can be
+// selected and ordered using the --columns parameter. Arrays nested under the
+// OData "value" property are unwrapped automatically. Long values are truncated
+// so that the table fits into the terminal width.
+// Example:
+// Name    State
+// Job 1   Running
+// Job 2   Successful
+type TableOutputWriter struct {
+	output      io.Writer
+	transformer Transformer
+	columns     []string
+	width       int
+}
+
+// formatValue collapses whitespace in strings so that every row of the table
+// fits into a single line.
+func (w TableOutputWriter) formatValue(value interface{}) string {
+	if v, ok := value.(string); ok {
+		return strings.Join(strings.Fields(v), " ")
+	}
+	return formatColumnValue(value)
+}
+
+func (w TableOutputWriter) createTable(value interface{}) ([]string, [][]string) {
+	switch result := unwrapODataValue(value).(type) {
+	case map[string]interface{}:
+		return w.createObjectTable([]map[string]interface{}{result})
+	case []interface{}:
+		rows := []map[string]interface{}{}
+		for _, item := range result {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, formatValueRows(result, w.formatValue)
+			}
+			rows = append(rows, row)
+		}
+		return w.createObjectTable(rows)
+	default:
+		return nil, [][]string{{w.formatValue(result)}}
+	}
+}
+
+// createObjectTable shows the simple values by default. Nested objects and
+// arrays are only shown when they are explicitly selected using --columns.
+func (w TableOutputWriter) createObjectTable(rows []map[string]interface{}) ([]string, [][]string) {
+	columns := w.columns
+	if len(columns) == 0 {
+		columns = collectColumns(rows, false)
+	}
+	return columns, formatObjectRows(rows, columns, w.formatValue)
+}
+
+func (w TableOutputWriter) columnWidths(header []string, cells [][]string) []int {
+	widths := []int{}
+	for _, row := range append([][]string{header}, cells...) {
+		for i, value := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(value))
+		}
+	}
+	return w.shrink(widths)
+}
+
+// shrink reduces the widest columns until the table fits into the terminal
+// width. Columns are never reduced below the minimum column width.
+func (w TableOutputWriter) shrink(widths []int) []int {
+	if w.width <= 0 || len(widths) == 0 {
+		return widths
+	}
+	for w.tableWidth(widths) > w.width {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= tableMinColumnWidth {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+func (w TableOutputWriter) tableWidth(widths []int) int {
+	total := len(tableColumnSeparator) * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+	return total
+}
+
+func (w TableOutputWriter) truncate(value string, width int) string {
+	if utf8.RuneCountInString(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	if width <= len(tableEllipsis) {
+		return string(runes[:width])
+	}
+	return string(runes[:width-len(tableEllipsis)]) + tableEllipsis
+}
+
+func (w TableOutputWriter) writeRow(row []string, widths []int) {
+	line := strings.Builder{}
+	for i, width := range widths {
+		value := ""
+		if i < len(row) {
+			value = w.truncate(row[i], width)
+		}
+		if i > 0 {
+			line.WriteString(tableColumnSeparator)
+		}
+		line.WriteString(value)
+		if i < len(widths)-1 {
+			line.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(value)))
+		}
+	}
+	_, _ = fmt.Fprint(w.output, strings.TrimRight(line.String(), " ")+ObjectSeparator)
+}
+
+func (w TableOutputWriter) write(value interface{}) error {
+	header, cells := w.createTable(value)
+	if len(header) == 0 && len(cells) == 0 {
+		return nil
+	}
+	widths := w.columnWidths(header, cells)
+	if header != nil {
+		w.writeRow(header, widths)
+	}
+	for _, row := range cells {
+		w.writeRow(row, widths)
+	}
+	return nil
+}
+
+func (w TableOutputWriter) WriteResponse(response ResponseInfo) error {
+	return writeRowsResponse(w.output, w.transformer, response, w.write)
+}
+
+func NewTableOutputWriter(output io.Writer, transformer Transformer, columns []string, width int) *TableOutputWriter {
+	return &TableOutputWriter{output, transformer, columns, width}
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func writeTable(t *testing.T, body string, columns []string, width int) string {
+	var output bytes.Buffer
+	writer := NewTableOutputWriter(&output, NewDefaultTransformer(), columns, width)
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(body)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	return output.String()
+}
+
+func TestTableWriterOutputsErrorStatusWhenResponseIsFailure(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewTableOutputWriter(&output, NewDefaultTransformer(), []string{}, 0)
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusBadRequest, "400 BadRequest", "HTTP/1.1", map[string][]string{}, bytes.NewReader([]byte{})))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "HTTP/1.1 400 BadRequest\n" {
+		t.Errorf("Should show HTTP error status, but got: %v", output.String())
+	}
+}
+
+func TestTableWriterOutputsObjectWithHeader(t *testing.T) {
+	result := writeTable(t, `{"Name":"my-job","Id":1}`, []string{}, 0)
+
+	expected := "Id  Name\n1   my-job\n"
+	if result != expected {
+		t.Errorf("Should show table with header, but got: %v", result)
+	}
+}
+
+func TestTableWriterAlignsColumns(t *testing.T) {
+	result := writeTable(t, `[{"Name":"a","State":"Running"},{"Name":"longer name","State":"Faulted"}]`, []string{}, 0)
+
+	expected := "Name         State\n" +
+		"a            Running\n" +
+		"longer name  Faulted\n"
+	if result != expected {
+		t.Errorf("Should show aligned columns, but got: %v", result)
+	}
+}
+
+func TestTableWriterUnwrapsODataValueArray(t *testing.T) {
+	result := writeTable(t, `{"@odata.context":"http://localhost","@odata.count":2,"value":[{"Name":"a"},{"Name":"b"}]}`, []string{}, 0)
+
+	expected := "Name\na\nb\n"
+	if result != expected {
+		t.Errorf("Should unwrap value array, but got: %v", result)
+	}
+}
+
+func TestTableWriterUsesColumnsInProvidedOrder(t *testing.T) {
+	result := writeTable(t, `[{"Id":1,"Name":"a","State":"Running","Key":"x"}]`, []string{"State", "name", "Missing"}, 0)
+
+	expected := "State    name  Missing\n" +
+		"Running  a\n"
+	if result != expected {
+		t.Errorf("Should only show the selected columns, but got: %v", result)
+	}
+}
+
+func TestTableWriterSkipsNestedValuesByDefault(t *testing.T) {
+	result := writeTable(t, `[{"Name":"a","Tags":["x"],"Robot":{"Id":1},"Info":null}]`, []string{}, 0)
+
+	expected := "Info  Name\n      a\n"
+	if result != expected {
+		t.Errorf("Should skip nested values, but got: %v", result)
+	}
+}
+
+func TestTableWriterRendersSelectedNestedValuesAsJson(t *testing.T) {
+	result := writeTable(t, `[{"Name":"a","Robot":{"Id":1}}]`, []string{"Name", "Robot"}, 0)
+
+	expected := "Name  Robot\na     {\"Id\":1}\n"
+	if result != expected {
+		t.Errorf("Should render nested value as json, but got: %v", result)
+	}
+}
+
+func TestTableWriterTruncatesLongValuesToWidth(t *testing.T) {
+	result := writeTable(t, `[{"Id":1,"Info":"this is a very long text which does not fit"}]`, []string{}, 20)
+
+	expected := "Id  Info\n1   this is a ver...\n"
+	if result != expected {
+		t.Errorf("Should truncate long values, but got: %v", result)
+	}
+}
+
+func TestTableWriterOutputsScalarArrayWithoutHeader(t *testing.T) {
+	result := writeTable(t, `["a","b"]`, []string{}, 0)
+
+	expected := "a\nb\n"
+	if result != expected {
+		t.Errorf("Should show scalar values, but got: %v", result)
+	}
+}
+
+func TestTableWriterOutputsNothingForEmptyArray(t *testing.T) {
+	result := writeTable(t, `{"value":[]}`, []string{}, 0)
+
+	if result != "" {
+		t.Errorf("Should not output anything, but got: %v", result)
+	}
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// NewTemplate parses the go template used by the --output template format
+// and registers the helper functions:
+//
+//	date:     {{date "2006-01-02" .StartTime}} formats RFC3339 or unix timestamps
+//	json:     {{json .Robot}} renders the value as compact json
+//	padLeft:  {{padLeft 10 .Id}} right-aligns the value
+//	padRight: {{padRight 20 .Name}} left-aligns the value
+//	join:     {{join ", " .Tags}} concatenates the array elements
+//	query:    {{query "date_diff(StartTime, EndTime)" .}} evaluates a JMESPath expression
+func NewTemplate(text string) (*template.Template, error) {
+	return template.New("output").
+		Funcs(template.FuncMap{
+			"date":     templateDate,
+			"json":     templateJson,
+			"padLeft":  templatePadLeft,
+			"padRight": templatePadRight,
+			"join":     templateJoin,
+			"query":    templateQuery,
+		}).
+		Parse(text)
+}
+
+func templateString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func templateDate(layout string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case int64:
+		return time.Unix(v, 0).UTC().Format(layout), nil
+	case float64:
+		return time.Unix(int64(v), 0).UTC().Format(layout), nil
+	case string:
+		for _, dateLayout := range dateLayouts {
+			date, err := time.Parse(dateLayout, v)
+			if err == nil {
+				return date.Format(layout), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("Cannot convert '%v' to date", value)
+}
+
+func templateJson(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func templatePadLeft(width int, value interface{}) string {
+	text := templateString(value)
+	padding := width - utf8.RuneCountInString(text)
+	if padding <= 0 {
+		return text
+	}
+	return strings.Repeat(" ", padding) + text
+}
+
+func templatePadRight(width int, value interface{}) string {
+	text := templateString(value)
+	padding := width - utf8.RuneCountInString(text)
+	if padding <= 0 {
+		return text
+	}
+	return text + strings.Repeat(" ", padding)
+}
+
+func templateJoin(separator string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []string:
+		return strings.Join(v, separator), nil
+	case []interface{}:
+		items := []string{}
+		for _, item := range v {
+			items = append(items, templateString(item))
+		}
+		return strings.Join(items, separator), nil
+	}
+	return "", fmt.Errorf("Cannot join '%v', value is not an array", value)
+}
+
+func templateQuery(expression string, value interface{}) (interface{}, error) {
+	// the template data contains normalized numbers which are converted back
+	// to the json representation expected by the JMESPath functions
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var input interface{}
+	err = json.Unmarshal(data, &input)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewJmesPathTransformer(expression).Execute(input)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeNumbers(result), nil
+}
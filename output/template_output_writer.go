@@ -0,0 +1,57 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// The TemplateOutputWriter formats the CLI output using a go template.
+//
+// It is used when the --output template parameter is provided together with
+// --template or --template-file. The template is executed after the --query
+// transformation which allows to combine filtering and free-form formatting.
+// Example:
+//
+//	--template '{{range .value}}{{.Name}}={{.Id}}{{"\n"}}{{end}}'
+type TemplateOutputWriter struct {
+	output      io.Writer
+	transformer Transformer
+	template    *template.Template
+}
+
+func (w TemplateOutputWriter) writeBody(body []byte) error {
+	var data interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		_, _ = fmt.Fprint(w.output, string(body))
+		return nil
+	}
+
+	transformedResult, err := w.transformer.Execute(data)
+	if err != nil {
+		return err
+	}
+	err = w.template.Execute(w.output, normalizeNumbers(transformedResult))
+	if err != nil {
+		return fmt.Errorf("Error executing template: %w", err)
+	}
+	return nil
+}
+
+func (w TemplateOutputWriter) WriteResponse(response ResponseInfo) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 && response.StatusCode >= 400 {
+		_, _ = fmt.Fprintf(w.output, "%s %s\n", response.Protocol, response.Status)
+		return nil
+	}
+	return w.writeBody(body)
+}
+
+func NewTemplateOutputWriter(output io.Writer, transformer Transformer, template *template.Template) *TemplateOutputWriter {
+	return &TemplateOutputWriter{output, transformer, template}
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, transformer Transformer, text string, body string) (string, error) {
+	tmpl, err := NewTemplate(text)
+	if err != nil {
+		t.Fatalf("Parsing template failed: %v", err)
+	}
+	var output bytes.Buffer
+	writer := NewTemplateOutputWriter(&output, transformer, tmpl)
+	err = writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(body)))
+	return output.String(), err
+}
+
+func TestTemplateWriterOutputsErrorStatusWhenResponseIsFailure(t *testing.T) {
+	tmpl, _ := NewTemplate("{{.Name}}")
+	var output bytes.Buffer
+	writer := NewTemplateOutputWriter(&output, NewDefaultTransformer(), tmpl)
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusBadRequest, "400 BadRequest", "HTTP/1.1", map[string][]string{}, bytes.NewReader([]byte{})))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "HTTP/1.1 400 BadRequest\n" {
+		t.Errorf("Should show HTTP error status, but got: %v", output.String())
+	}
+}
+
+func TestTemplateWriterRendersRangeOverValue(t *testing.T) {
+	result, err := writeTemplate(t, NewDefaultTransformer(), `{{range .value}}{{.Name}}={{.Id}}{{"\n"}}{{end}}`, `{"value":[{"Name":"a","Id":1},{"Name":"b","Id":12345678901234}]}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != "a=1\nb=12345678901234\n" {
+		t.Errorf("Should render template, but got: %v", result)
+	}
+}
+
+func TestTemplateWriterAppliesTransformerBeforeTemplate(t *testing.T) {
+	result, err := writeTemplate(t, NewJmesPathTransformer("value[?Id > `1`]"), `{{range .}}{{.Name}}{{end}}`, `{"value":[{"Name":"a","Id":1},{"Name":"b","Id":2}]}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != "b" {
+		t.Errorf("Should apply query before template, but got: %v", result)
+	}
+}
+
+func TestTemplateWriterFormatsDates(t *testing.T) {
+	result, err := writeTemplate(t, NewDefaultTransformer(), `{{date "2006-01-02 15:04" .Start}}|{{date "2006-01-02" .Unix}}|{{date "2006" .Missing}}`, `{"Start":"2024-03-05T10:20:30.123Z","Unix":0}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != "2024-03-05 10:20|1970-01-01|" {
+		t.Errorf("Should format dates, but got: %v", result)
+	}
+}
+
+func TestTemplateWriterInvalidDateReturnsError(t *testing.T) {
+	_, err := writeTemplate(t, NewDefaultTransformer(), `{{date "2006" .Start}}`, `{"Start":"yesterday"}`)
+
+	if err == nil || !strings.Contains(err.Error(), "Cannot convert 'yesterday' to date") {
+		t.Errorf("Should return date error, but got: %v", err)
+	}
+}
+
+func TestTemplateWriterRendersJson(t *testing.T) {
+	result, err := writeTemplate(t, NewDefaultTransformer(), `{{json .Robot}}`, `{"Robot":{"Name":"r1","Id":5}}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != `{"Id":5,"Name":"r1"}` {
+		t.Errorf("Should render json, but got: %v", result)
+	}
+}
+
+func TestTemplateWriterPadsValues(t *testing.T) {
+	result, err := writeTemplate(t, NewDefaultTransformer(), `[{{padRight 6 .Name}}][{{padLeft 4 .Id}}][{{.Name | padRight 1}}]`, `{"Name":"abc","Id":7}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != "[abc   ][   7][abc]" {
+		t.Errorf("Should pad values, but got: %v", result)
+	}
+}
+
+func TestTemplateWriterJoinsArrays(t *testing.T) {
+	result, err := writeTemplate(t, NewDefaultTransformer(), `{{join ", " .Tags}}`, `{"Tags":["a",1,true]}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != "a, 1, true" {
+		t.Errorf("Should join values, but got: %v", result)
+	}
+}
+
+func TestTemplateWriterEvaluatesQuery(t *testing.T) {
+	result, err := writeTemplate(t, NewDefaultTransformer(), `{{range .value}}{{.Name}}: {{query "date_diff(StartTime, EndTime, 'minutes')" .}}{{"\n"}}{{end}}`, `{"value":[{"Name":"a","StartTime":"2024-01-25T12:00:00Z","EndTime":"2024-01-25T12:30:00Z"}]}`)
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if result != "a: 30\n" {
+		t.Errorf("Should evaluate query, but got: %v", result)
+	}
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/goccy/go-yaml"
+)
+
+// The YamlOutputWriter formats the CLI output as yaml.
+//
+// It is used when the --output yaml parameter is provided. The keys of all
+// objects are sorted so that the output is stable and can be kept under
+// version control.
+// Example:
+//
+//	foo: bar
+//	items:
+//	- id: 1
+type YamlOutputWriter struct {
+	output      io.Writer
+	transformer Transformer
+}
+
+func (w YamlOutputWriter) writeBody(body []byte) error {
+	var data interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		_, _ = fmt.Fprint(w.output, string(body))
+		return nil
+	}
+
+	transformedResult, err := w.transformer.Execute(data)
+	if err != nil {
+		return err
+	}
+	result, err := yaml.MarshalWithOptions(normalizeNumbers(transformedResult), yaml.Indent(2))
+	if err != nil {
+		return err
+	}
+	_, _ = w.output.Write(result)
+	return nil
+}
+
+func (w YamlOutputWriter) WriteResponse(response ResponseInfo) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 && response.StatusCode >= 400 {
+		_, _ = fmt.Fprintf(w.output, "%s %s\n", response.Protocol, response.Status)
+		return nil
+	}
+	return w.writeBody(body)
+}
+
+func NewYamlOutputWriter(output io.Writer, transformer Transformer) *YamlOutputWriter {
+	return &YamlOutputWriter{output, transformer}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestYamlWriterOutputsErrorStatusWhenResponseIsFailure(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewYamlOutputWriter(&output, NewDefaultTransformer())
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusBadRequest, "400 BadRequest", "HTTP/1.1", map[string][]string{}, bytes.NewReader([]byte{})))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "HTTP/1.1 400 BadRequest\n" {
+		t.Errorf("Should show HTTP error status, but got: %v", output.String())
+	}
+}
+
+func TestYamlWriterOutputsResponseBodySortedByKeys(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewYamlOutputWriter(&output, NewDefaultTransformer())
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(`{"b":"world","a":{"d":true,"c":null},"e":["x",{"g":1,"f":2}]}`)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	expected := `a:
+  c: null
+  d: true
+b: world
+e:
+- x
+- f: 2
+  g: 1
+`
+	if output.String() != expected {
+		t.Errorf("Should show yaml sorted by keys, but got: %v", output.String())
+	}
+}
+
+func TestYamlWriterOutputsNumbers(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewYamlOutputWriter(&output, NewDefaultTransformer())
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(`{"id":12345678901234,"ratio":1.5,"text":"123"}`)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	expected := "id: 12345678901234\nratio: 1.5\ntext: \"123\"\n"
+	if output.String() != expected {
+		t.Errorf("Should show integers without decimals, but got: %v", output.String())
+	}
+}
+
+func TestYamlWriterAppliesTransformer(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewYamlOutputWriter(&output, NewJmesPathTransformer("value[].Name"))
+
+	err := writer.WriteResponse(*NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewBufferString(`{"value":[{"Name":"a"},{"Name":"b"}]}`)))
+
+	if err != nil {
+		t.Errorf("Writing response failed: %v", err)
+	}
+	if output.String() != "- a\n- b\n" {
+		t.Errorf("Should apply query before formatting, but got: %v", output.String())
+	}
+}
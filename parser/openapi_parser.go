@@ -3,6 +3,8 @@ package parser
 import (
 	"fmt"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -11,6 +13,9 @@ import (
 const DefaultServerBaseUrl = "https://cloud.uipath.com"
 const RawBodyParameterName = "$file"
 const CustomNameExtension = "x-uipathcli-name"
+const PaginationExtension = "x-uipathcli-pagination"
+
+var scopesDescriptionRegex = regexp.MustCompile(`OAuth required scopes: ([^\n]+)`)
 
 // The OpenApiParser parses OpenAPI (2.x and 3.x) specifications.
 // It creates the Definition structure with all the information about the available
@@ -37,6 +42,72 @@ func (p OpenApiParser) getCustomName(extensions map[string]interface{}) string {
 	}
 }
 
+func (p OpenApiParser) getStringValue(data map[string]interface{}, key string) string {
+	value, ok := data[key].(string)
+	if !ok {
+		return ""
+	}
+	return value
+}
+
+func (p OpenApiParser) getPagination(extensions map[string]interface{}) *OperationPagination {
+	pagination, ok := extensions[PaginationExtension].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	tokenParameter := p.getStringValue(pagination, "tokenParameter")
+	tokenField := p.getStringValue(pagination, "tokenField")
+	itemsField := p.getStringValue(pagination, "itemsField")
+	if tokenParameter == "" || tokenField == "" || itemsField == "" {
+		return nil
+	}
+	return NewOperationPagination(tokenParameter, tokenField, itemsField)
+}
+
+// getSecurityScopes returns the OAuth scope groups of the security requirements
+// defined on the operation or, if not set, on the document. The scopes of a
+// requirement are all required while the requirements are alternatives.
+func (p OpenApiParser) getSecurityScopes(document openapi3.T, operation openapi3.Operation) [][]string {
+	security := document.Security
+	if operation.Security != nil {
+		security = *operation.Security
+	}
+	scopes := [][]string{}
+	for _, requirement := range security {
+		names := []string{}
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		groups := [][]string{}
+		for _, name := range names {
+			for _, scope := range requirement[name] {
+				groups = appendScopeGroup(groups, []string{scope})
+			}
+		}
+		scopes = orScopes(scopes, groups)
+	}
+	return scopes
+}
+
+// getDescriptionScopes extracts the OAuth scope groups from the operation
+// description, e.g. "OAuth required scopes: OR.Folders or OR.Folders.Read."
+func (p OpenApiParser) getDescriptionScopes(description string) [][]string {
+	match := scopesDescriptionRegex.FindStringSubmatch(description)
+	if match == nil {
+		return [][]string{}
+	}
+	return newScopeExpression(match[1]).Parse()
+}
+
+func (p OpenApiParser) getScopes(document openapi3.T, operation openapi3.Operation) [][]string {
+	scopes := p.getSecurityScopes(document, operation)
+	if len(scopes) > 0 {
+		return scopes
+	}
+	return p.getDescriptionScopes(operation.Description)
+}
+
 func (p OpenApiParser) contains(strs []string, str string) bool {
 	for _, s := range strs {
 		if s == str {
@@ -323,7 +394,9 @@ func (p OpenApiParser) parseOperation(definitionName string, document openapi3.T
 	category := p.getCategory(definitionName, document, operation)
 	name := p.getOperationName(method, route, category, operation)
 	contentType, parameters := p.parseOperationParameters(operation, routeParameters)
-	return *NewOperation(name, operation.Summary, operation.Description, method, baseUri, route, contentType, parameters, nil, false, category)
+	pagination := p.getPagination(operation.Extensions)
+	scopes := p.getScopes(document, operation)
+	return *NewOperation(name, operation.Summary, operation.Description, method, baseUri, route, contentType, parameters, nil, false, category, pagination, scopes)
 }
 
 func (p OpenApiParser) parsePath(definitionName string, document openapi3.T, baseUri url.URL, route string, pathItem openapi3.PathItem) []Operation {
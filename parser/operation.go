@@ -22,8 +22,10 @@ type Operation struct {
 	Plugin      plugin.CommandPlugin
 	Hidden      bool
 	Category    *OperationCategory
+	Pagination  *OperationPagination
+	Scopes      [][]string
 }
 
-func NewOperation(name string, summary string, description string, method string, baseUri url.URL, route string, contentType string, parameters []Parameter, plugin plugin.CommandPlugin, hidden bool, category *OperationCategory) *Operation {
-	return &Operation{name, summary, description, method, baseUri, route, contentType, parameters, plugin, hidden, category}
+func NewOperation(name string, summary string, description string, method string, baseUri url.URL, route string, contentType string, parameters []Parameter, plugin plugin.CommandPlugin, hidden bool, category *OperationCategory, pagination *OperationPagination, scopes [][]string) *Operation {
+	return &Operation{name, summary, description, method, baseUri, route, contentType, parameters, plugin, hidden, category, pagination, scopes}
 }
@@ -0,0 +1,20 @@
+package parser
+
+// OperationPagination describes how to retrieve all pages of an operation
+// which uses continuation tokens instead of OData paging.
+//
+// The definition provides this information using the x-uipathcli-pagination extension:
+//
+//	x-uipathcli-pagination:
+//	  tokenParameter: continuationToken
+//	  tokenField: continuationToken
+//	  itemsField: items
+type OperationPagination struct {
+	TokenParameter string
+	TokenField     string
+	ItemsField     string
+}
+
+func NewOperationPagination(tokenParameter string, tokenField string, itemsField string) *OperationPagination {
+	return &OperationPagination{tokenParameter, tokenField, itemsField}
+}
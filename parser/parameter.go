@@ -26,6 +26,7 @@ const (
 	ParameterTypeNumberArray  = "numberArray"
 	ParameterTypeBooleanArray = "booleanArray"
 	ParameterTypeObjectArray  = "objectArray"
+	ParameterTypeBinaryArray  = "binaryArray"
 )
 
 const (
@@ -42,7 +43,8 @@ func (p Parameter) IsArray() bool {
 		p.Type == ParameterTypeIntegerArray ||
 		p.Type == ParameterTypeNumberArray ||
 		p.Type == ParameterTypeObjectArray ||
-		p.Type == ParameterTypeStringArray
+		p.Type == ParameterTypeStringArray ||
+		p.Type == ParameterTypeBinaryArray
 }
 
 func NewParameter(name string, t string, description string, in string, fieldName string, required bool, defaultValue interface{}, allowedValues []interface{}, hidden bool, parameters []Parameter) *Parameter {
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+)
+
+// The scopeExpression parses the OAuth scope expression from the operation
+// description into scope groups. All groups are required and each group
+// contains the alternative scopes which satisfy it.
+//
+// Example:
+//
+//	"(OR.Folders or OR.Folders.Read) and OR.Tasks"
+//
+// results in the groups [[OR.Folders OR.Folders.Read] [OR.Tasks]].
+type scopeExpression struct {
+	tokens   []string
+	position int
+}
+
+func (e *scopeExpression) peek() string {
+	if e.position >= len(e.tokens) {
+		return ""
+	}
+	return e.tokens[e.position]
+}
+
+func (e *scopeExpression) next() string {
+	token := e.peek()
+	e.position++
+	return token
+}
+
+func (e *scopeExpression) parseOr() [][]string {
+	result := e.parseAnd()
+	for e.peek() == "or" {
+		e.next()
+		result = orScopes(result, e.parseAnd())
+	}
+	return result
+}
+
+func (e *scopeExpression) parseAnd() [][]string {
+	result := e.parseTerm()
+	for e.peek() == "and" {
+		e.next()
+		result = andScopes(result, e.parseTerm())
+	}
+	return result
+}
+
+func (e *scopeExpression) parseTerm() [][]string {
+	token := e.next()
+	switch token {
+	case "", ")", "and", "or":
+		return [][]string{}
+	case "(":
+		result := e.parseOr()
+		if e.peek() == ")" {
+			e.next()
+		}
+		return result
+	}
+	return [][]string{{token}}
+}
+
+func (e *scopeExpression) Parse() [][]string {
+	return e.parseOr()
+}
+
+func newScopeExpression(expression string) *scopeExpression {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	tokens := []string{}
+	for _, word := range strings.Fields(expression) {
+		word = strings.TrimSuffix(word, ".")
+		if word != "" {
+			tokens = append(tokens, word)
+		}
+	}
+	return &scopeExpression{tokens, 0}
+}
+
+func appendScopeGroup(groups [][]string, group []string) [][]string {
+	for _, existing := range groups {
+		if slices.Equal(existing, group) {
+			return groups
+		}
+	}
+	return append(groups, group)
+}
+
+// andScopes combines two scope expressions which are both required.
+func andScopes(left [][]string, right [][]string) [][]string {
+	result := [][]string{}
+	for _, group := range left {
+		result = appendScopeGroup(result, group)
+	}
+	for _, group := range right {
+		result = appendScopeGroup(result, group)
+	}
+	return result
+}
+
+// orScopes combines two alternative scope expressions. Every resulting group
+// contains the scopes of one group from each side.
+func orScopes(left [][]string, right [][]string) [][]string {
+	if len(left) == 0 || len(right) == 0 {
+		return andScopes(left, right)
+	}
+	result := [][]string{}
+	for _, leftGroup := range left {
+		for _, rightGroup := range right {
+			group := slices.Clone(leftGroup)
+			for _, scope := range rightGroup {
+				if !slices.Contains(group, scope) {
+					group = append(group, scope)
+				}
+			}
+			result = appendScopeGroup(result, group)
+		}
+	}
+	return result
+}
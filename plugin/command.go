@@ -11,6 +11,8 @@ type Command struct {
 	Parameters  []CommandParameter
 	Hidden      bool
 	Category    *CommandCategory
+	DryRun      bool
+	DryRunAuth  bool
 }
 
 func (c *Command) WithCategory(name string, summary string, description string) *Command {
@@ -30,6 +32,16 @@ func (c *Command) WithParameter(parameter *CommandParameter) *Command {
 	return c
 }
 
+// WithDryRun declares that the command supports the --dry-run flag and does not
+// perform any changes in this case. Commands which need to read data from the
+// service during the dry run, e.g. to plan the changes, can request to be
+// authenticated.
+func (c *Command) WithDryRun(authenticate bool) *Command {
+	c.DryRun = true
+	c.DryRunAuth = authenticate
+	return c
+}
+
 func (c *Command) IsHidden() *Command {
 	c.Hidden = true
 	return c
@@ -12,6 +12,7 @@ const (
 	ParameterTypeNumberArray  = "numberArray"
 	ParameterTypeBooleanArray = "booleanArray"
 	ParameterTypeObjectArray  = "objectArray"
+	ParameterTypeBinaryArray  = "binaryArray"
 )
 
 // CommandParameter defines the parameters the plugin command supports.
@@ -14,8 +14,9 @@ type ExecutionContext struct {
 	Auth         AuthResult
 	IdentityUri  url.URL
 	Input        stream.Stream
-	Parameters   []ExecutionParameter
+	Parameters   ExecutionParameters
 	Debug        bool
+	DryRun       bool
 	Settings     ExecutionSettings
 }
 
@@ -28,6 +29,7 @@ func NewExecutionContext(
 	input stream.Stream,
 	parameters []ExecutionParameter,
 	debug bool,
+	dryRun bool,
 	settings ExecutionSettings,
 ) *ExecutionContext {
 	return &ExecutionContext{
@@ -39,6 +41,7 @@ func NewExecutionContext(
 		input,
 		parameters,
 		debug,
+		dryRun,
 		settings,
 	}
 }
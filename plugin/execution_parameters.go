@@ -0,0 +1,42 @@
+package plugin
+
+// ExecutionParameters is a list of execution parameters which provides typed
+// access to the parameter values by their name.
+type ExecutionParameters []ExecutionParameter
+
+func (p ExecutionParameters) String(name string, defaultValue string) string {
+	if data, ok := p.find(name).(string); ok {
+		return data
+	}
+	return defaultValue
+}
+
+func (p ExecutionParameters) StringArray(name string) []string {
+	if data, ok := p.find(name).([]string); ok {
+		return data
+	}
+	return []string{}
+}
+
+func (p ExecutionParameters) Int(name string, defaultValue int) int {
+	if data, ok := p.find(name).(int); ok {
+		return data
+	}
+	return defaultValue
+}
+
+func (p ExecutionParameters) Bool(name string, defaultValue bool) bool {
+	if data, ok := p.find(name).(bool); ok {
+		return data
+	}
+	return defaultValue
+}
+
+func (p ExecutionParameters) find(name string) interface{} {
+	for _, parameter := range p {
+		if parameter.Name == name {
+			return parameter.Value
+		}
+	}
+	return nil
+}
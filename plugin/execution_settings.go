@@ -2,28 +2,46 @@ package plugin
 
 import (
 	"time"
+
+	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/resiliency"
 )
 
 // The ExecutionSettings provides global settings for executing commands.
 type ExecutionSettings struct {
-	OperationId string
-	Header      map[string]string
-	Timeout     time.Duration
-	MaxAttempts int
-	Insecure    bool
+	OperationId        string
+	Header             map[string]string
+	Timeout            time.Duration
+	Retry              resiliency.RetryPolicy
+	RetryNonIdempotent bool
+	RateLimit          network.RateLimitSettings
+	Connection         network.ConnectionSettings
+	Tracer             *network.Tracer
+	Insecure           bool
+	OutputFile         string
 }
 
 func NewExecutionSettings(
 	operationId string,
 	header map[string]string,
 	timeout time.Duration,
-	maxAttempts int,
-	insecure bool) *ExecutionSettings {
+	retry resiliency.RetryPolicy,
+	retryNonIdempotent bool,
+	rateLimit network.RateLimitSettings,
+	connection network.ConnectionSettings,
+	tracer *network.Tracer,
+	insecure bool,
+	outputFile string) *ExecutionSettings {
 	return &ExecutionSettings{
 		operationId,
 		header,
 		timeout,
-		maxAttempts,
+		retry,
+		retryNonIdempotent,
+		rateLimit,
+		connection,
+		tracer,
 		insecure,
+		outputFile,
 	}
 }
@@ -172,7 +172,7 @@ func (m ModuleManager) download(definition ModuleDefinition, destination string,
 	defer func() { _ = out.Close() }()
 
 	request := network.NewHttpGetRequest(definition.Url, nil, http.Header{})
-	clientSettings := network.NewHttpClientSettings(false, "", map[string]string{}, 0, 1, false)
+	clientSettings := network.NewHttpClientSettings(false, "", map[string]string{}, 0, *resiliency.NewDefaultRetryPolicy(1), false, network.RateLimitSettings{}, network.ConnectionSettings{}, nil, false)
 	client := network.NewHttpClient(nil, *clientSettings)
 	response, err := client.Send(request)
 	if err != nil {
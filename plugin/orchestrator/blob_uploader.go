@@ -0,0 +1,74 @@
+// Package orchestrator defines the common functionality required by each
+// of the orchestrator bucket command plugins like download, upload and sync.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/stream"
+)
+
+// The BlobUploader uploads a file to the write url of a bucket blob.
+//
+// The file is streamed to the storage and the progress callback allows
+// wrapping the request body, e.g. to display a progress bar.
+type BlobUploader struct {
+	client *network.HttpClient
+}
+
+func (u BlobUploader) Upload(url string, file stream.Stream, progress func(reader io.Reader, length int64) io.Reader) error {
+	context, cancel := context.WithCancelCause(context.Background())
+	request := u.createUploadRequest(url, file, progress, cancel)
+	response, err := u.client.SendWithContext(request, context)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("Error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusCreated {
+		return network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
+	}
+	return nil
+}
+
+func (u BlobUploader) createUploadRequest(url string, file stream.Stream, progress func(reader io.Reader, length int64) io.Reader, cancel context.CancelCauseFunc) *network.HttpRequest {
+	bodyReader, bodyWriter := io.Pipe()
+	contentType, contentLength := u.writeBody(bodyWriter, file, cancel)
+	uploadReader := progress(bodyReader, contentLength)
+
+	header := http.Header{
+		"Content-Type":   {contentType},
+		"x-ms-blob-type": {"BlockBlob"},
+	}
+	return network.NewHttpPutRequest(url, nil, header, uploadReader, contentLength)
+}
+
+func (u BlobUploader) writeBody(bodyWriter *io.PipeWriter, input stream.Stream, cancel context.CancelCauseFunc) (string, int64) {
+	go func() {
+		defer func() { _ = bodyWriter.Close() }()
+		data, err := input.Data()
+		if err != nil {
+			cancel(err)
+			return
+		}
+		defer func() { _ = data.Close() }()
+		_, err = io.Copy(bodyWriter, data)
+		if err != nil {
+			cancel(err)
+			return
+		}
+	}()
+	size, _ := input.Size()
+	return "application/octet-stream", size
+}
+
+func NewBlobUploader(client *network.HttpClient) *BlobUploader {
+	return &BlobUploader{client}
+}
@@ -4,15 +4,16 @@
 package download
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/UiPath/uipathcli/log"
 	"github.com/UiPath/uipathcli/output"
 	"github.com/UiPath/uipathcli/plugin"
+	"github.com/UiPath/uipathcli/plugin/orchestrator"
 	"github.com/UiPath/uipathcli/utils/api"
 	"github.com/UiPath/uipathcli/utils/network"
 	"github.com/UiPath/uipathcli/utils/visualization"
@@ -21,6 +22,9 @@ import (
 // The DownloadCommand is a custom command for the orchestrator service which makes downloading
 // files more convenient. It provides a wrapper over retrieving the read url and actually
 // performing the download.
+//
+// When the --output-file parameter is provided, the file is downloaded using range
+// requests into a .partial file which allows to resume interrupted downloads.
 type DownloadCommand struct{}
 
 func (c DownloadCommand) Command() plugin.Command {
@@ -32,15 +36,46 @@ func (c DownloadCommand) Command() plugin.Command {
 		WithParameter(plugin.NewParameter("key", plugin.ParameterTypeInteger, "The Bucket Id").
 			WithRequired(true)).
 		WithParameter(plugin.NewParameter("path", plugin.ParameterTypeString, "The BlobFile full path").
-			WithRequired(true))
+			WithRequired(true)).
+		WithParameter(plugin.NewParameter("parallelism", plugin.ParameterTypeInteger, "The number of ranges to download in parallel when using --output-file (default: 1)"))
 }
 
 func (c DownloadCommand) Execute(ctx plugin.ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
-	writeUrl, err := c.getReadUrl(ctx, logger)
+	parallelism := c.getIntParameter("parallelism", 1, ctx.Parameters)
+	if parallelism < 1 {
+		return fmt.Errorf("Invalid value for 'parallelism': %d", parallelism)
+	}
+	readUrl, err := c.getReadUrl(ctx, logger)
 	if err != nil {
 		return err
 	}
-	return c.download(ctx, writer, logger, writeUrl)
+	if ctx.Settings.OutputFile != "" {
+		return c.downloadFile(ctx, logger, readUrl, ctx.Settings.OutputFile, parallelism)
+	}
+	return c.download(ctx, writer, logger, readUrl)
+}
+
+func (c DownloadCommand) downloadFile(ctx plugin.ExecutionContext, logger log.Logger, url string, destination string, parallelism int) error {
+	client := network.NewHttpClient(logger, c.httpClientSettings(ctx))
+	downloadBar := visualization.NewProgressBar(logger)
+	defer downloadBar.Remove()
+	startTime := time.Now()
+	resumed := int64(-1)
+	downloader := orchestrator.NewRangeDownloader(client, url, ctx.Settings.Retry, parallelism, orchestrator.DefaultChunkSize, func(downloaded int64, total int64) {
+		if resumed < 0 {
+			resumed = downloaded
+		}
+		if total < 10*1024*1024 {
+			return
+		}
+		bytesPerSecond := int64(0)
+		seconds := time.Since(startTime).Seconds()
+		if seconds > 0 {
+			bytesPerSecond = int64(float64(downloaded-resumed) / seconds)
+		}
+		downloadBar.UpdateProgress("downloading...", downloaded, total, bytesPerSecond)
+	})
+	return downloader.Download(destination)
 }
 
 func (c DownloadCommand) download(ctx plugin.ExecutionContext, writer output.OutputWriter, logger log.Logger, url string) error {
@@ -54,15 +89,7 @@ func (c DownloadCommand) download(ctx plugin.ExecutionContext, writer output.Out
 	downloadBar := visualization.NewProgressBar(logger)
 	downloadReader := c.progressReader("downloading...", "completing    ", response.Body, response.ContentLength, downloadBar)
 	defer downloadBar.Remove()
-	body, err := io.ReadAll(downloadReader)
-	if err != nil {
-		return fmt.Errorf("Error reading response body: %w", err)
-	}
-	err = writer.WriteResponse(*output.NewResponseInfo(response.StatusCode, response.Status, response.Proto, response.Header, bytes.NewReader(body)))
-	if err != nil {
-		return err
-	}
-	return nil
+	return writer.WriteResponse(*output.NewResponseInfo(response.StatusCode, response.Status, response.Proto, response.Header, downloadReader))
 }
 
 func (c DownloadCommand) progressReader(text string, completedText string, reader io.Reader, length int64, progressBar *visualization.ProgressBar) io.Reader {
@@ -85,16 +112,16 @@ func (c DownloadCommand) getReadUrl(ctx plugin.ExecutionContext, logger log.Logg
 	if ctx.Tenant == "" {
 		return "", errors.New("Tenant is not set")
 	}
-	folderId := c.getIntParameter("folder-id", ctx.Parameters)
-	bucketId := c.getIntParameter("key", ctx.Parameters)
-	path := c.getStringParameter("path", ctx.Parameters)
+	folderId := c.getIntParameter("folder-id", 0, ctx.Parameters)
+	bucketId := c.getIntParameter("key", 0, ctx.Parameters)
+	path := c.getStringParameter("path", "", ctx.Parameters)
 
 	client := api.NewOrchestratorClient(ctx.BaseUri, ctx.Organization, ctx.Tenant, ctx.Auth.Token, ctx.Debug, ctx.Settings, logger)
 	return client.GetReadUrl(folderId, bucketId, path)
 }
 
-func (c DownloadCommand) getStringParameter(name string, parameters []plugin.ExecutionParameter) string {
-	result := ""
+func (c DownloadCommand) getStringParameter(name string, defaultValue string, parameters []plugin.ExecutionParameter) string {
+	result := defaultValue
 	for _, p := range parameters {
 		if p.Name == name {
 			if data, ok := p.Value.(string); ok {
@@ -106,8 +133,8 @@ func (c DownloadCommand) getStringParameter(name string, parameters []plugin.Exe
 	return result
 }
 
-func (c DownloadCommand) getIntParameter(name string, parameters []plugin.ExecutionParameter) int {
-	result := 0
+func (c DownloadCommand) getIntParameter(name string, defaultValue int, parameters []plugin.ExecutionParameter) int {
+	result := defaultValue
 	for _, p := range parameters {
 		if p.Name == name {
 			if data, ok := p.Value.(int); ok {
@@ -125,7 +152,11 @@ func (c DownloadCommand) httpClientSettings(ctx plugin.ExecutionContext) network
 		ctx.Settings.OperationId,
 		ctx.Settings.Header,
 		ctx.Settings.Timeout,
-		ctx.Settings.MaxAttempts,
+		ctx.Settings.Retry,
+		ctx.Settings.RetryNonIdempotent,
+		ctx.Settings.RateLimit,
+		ctx.Settings.Connection,
+		ctx.Settings.Tracer,
 		ctx.Settings.Insecure)
 }
 
@@ -3,9 +3,12 @@ package download
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/UiPath/uipathcli/test"
 )
@@ -182,6 +185,48 @@ servers:
 	}
 }
 
+func TestDownloadToOutputFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("hello-world"))
+	}))
+	defer srv.Close()
+
+	definition := `
+servers:
+- url: https://cloud.uipath.com/{organization}/{tenant}/orchestrator_
+  description: The production url
+  variables:
+    organization:
+      description: The organization name (or id)
+      default: my-org
+    tenant:
+      description: The tenant name (or id)
+      default: my-tenant
+`
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", definition).
+		WithCommandPlugin(NewDownloadCommand()).
+		WithResponse(http.StatusOK, `{"Uri":"`+srv.URL+`"}`).
+		Build()
+
+	path := test.TempFile(t)
+	result := test.RunCli([]string{"orchestrator", "buckets", "download", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--output-file", path}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if result.StdOut != "" {
+		t.Errorf("Expected stdout to be empty, but got: %v", result.StdOut)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected output file to contain file content, but got: %v", string(data))
+	}
+}
+
 func TestDownloadWithDebugOutput(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -217,3 +262,152 @@ servers:
 		t.Errorf("Expected stderr to contain download request, but got: %v", result.StdErr)
 	}
 }
+
+func TestDownloadToOutputFileRemovesPartialFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"0x8DC1"`)
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", downloadDefinition).
+		WithCommandPlugin(NewDownloadCommand()).
+		WithResponse(http.StatusOK, `{"Uri":"`+srv.URL+`"}`).
+		Build()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	result := test.RunCli([]string{"orchestrator", "buckets", "download", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--output-file", path}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if result.StdOut != "" {
+		t.Errorf("Expected stdout to be empty, but got: %v", result.StdOut)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected output file to contain file content, but got: %v", string(data))
+	}
+	if _, err := os.Stat(path + ".partial"); !os.IsNotExist(err) {
+		t.Errorf("Expected partial file to be removed, but got: %v", err)
+	}
+}
+
+func TestDownloadResumesPartialFile(t *testing.T) {
+	rangeHeader := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rangeHeader = r.Header.Get("Range")
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", downloadDefinition).
+		WithCommandPlugin(NewDownloadCommand()).
+		WithResponse(http.StatusOK, `{"Uri":"`+srv.URL+`"}`).
+		Build()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	_ = os.WriteFile(path+".partial", []byte("hello"), 0600)
+	result := test.RunCli([]string{"orchestrator", "buckets", "download", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--output-file", path}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if rangeHeader != "bytes=5-" {
+		t.Errorf("Expected download to continue from partial file, but got range: %v", rangeHeader)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected output file to contain file content, but got: %v", string(data))
+	}
+}
+
+func TestDownloadRetriesInterruptedConnectionWithRange(t *testing.T) {
+	ranges := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			w.Header().Set("Content-Length", "11")
+			w.Header().Set("Content-Range", "bytes 0-10/11")
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte("hello"))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", downloadDefinition).
+		WithCommandPlugin(NewDownloadCommand()).
+		WithResponse(http.StatusOK, `{"Uri":"`+srv.URL+`"}`).
+		Build()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	result := test.RunCli([]string{"orchestrator", "buckets", "download", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--output-file", path, "--retry-initial-delay", "1ms"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if len(ranges) != 2 || ranges[0] != "bytes=0-" || ranges[1] != "bytes=5-" {
+		t.Errorf("Expected interrupted download to be resumed, but got ranges: %v", ranges)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected output file to contain file content, but got: %v", string(data))
+	}
+}
+
+func TestDownloadWithInvalidChecksumReturnsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-blob-content-md5", "AAAAAAAAAAAAAAAAAAAAAA==")
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", downloadDefinition).
+		WithCommandPlugin(NewDownloadCommand()).
+		WithResponse(http.StatusOK, `{"Uri":"`+srv.URL+`"}`).
+		Build()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	result := test.RunCli([]string{"orchestrator", "buckets", "download", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--output-file", path}, context)
+
+	expected := "Downloaded file checksum '2095312189753de6ad47dfe20cbe97ec' does not match the expected checksum '00000000000000000000000000000000'"
+	if !strings.Contains(result.StdErr, expected) {
+		t.Errorf("Expected stderr to show checksum error, but got: %v", result.StdErr)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected output file not to exist, but got: %v", err)
+	}
+}
+
+func TestDownloadWithInvalidParallelismShowsValidationError(t *testing.T) {
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", downloadDefinition).
+		WithCommandPlugin(NewDownloadCommand()).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "download", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--output-file", "file.txt", "--parallelism", "0"}, context)
+
+	if !strings.Contains(result.StdErr, "Invalid value for 'parallelism': 0") {
+		t.Errorf("Expected stderr to show invalid parallelism error, but got: %v", result.StdErr)
+	}
+}
+
+const downloadDefinition = `
+servers:
+- url: https://cloud.uipath.com/{organization}/{tenant}/orchestrator_
+  description: The production url
+  variables:
+    organization:
+      description: The organization name (or id)
+      default: my-org
+    tenant:
+      description: The tenant name (or id)
+      default: my-tenant
+`
@@ -0,0 +1,441 @@
+package orchestrator
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // MD5 is used by the storage backends to provide the file checksum
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/resiliency"
+)
+
+const partialFileExtension = ".partial"
+const etagFileExtension = ".etag"
+const DefaultChunkSize = 8 * 1024 * 1024
+
+var contentRangePattern = regexp.MustCompile(`^bytes (\d+)-(\d+)/(\d+|\*)$`)
+var unsatisfiedRangePattern = regexp.MustCompile(`^bytes \*/(\d+)$`)
+
+var errFileChanged = errors.New("File changed on the server while downloading, please restart the download")
+var errRestart = errors.New("Storage returned the complete file instead of the requested range")
+
+// The RangeDownloader downloads a file using HTTP range requests.
+//
+// The data is written to a .partial file next to the destination so that an
+// interrupted download continues where it stopped instead of starting from
+// zero. Failed ranges are requested again starting at the last received byte.
+// When parallelism is greater than one, the file is split into chunks which
+// are fetched in parallel and appended in order, so the .partial file always
+// contains a contiguous prefix of the file.
+//
+// The ETag of the file is stored in a .partial.etag file and sent in the
+// If-Range header when the download is resumed. In case the file changed in
+// the meantime, the storage returns the complete file and the download
+// restarts from zero.
+//
+// The file is moved to its destination once the size and the checksum from the
+// Content-MD5 or x-ms-blob-content-md5 header (if provided) have been verified.
+type RangeDownloader struct {
+	client      *network.HttpClient
+	url         string
+	retry       resiliency.RetryPolicy
+	parallelism int
+	chunkSize   int64
+	progress    func(downloaded int64, total int64)
+	etagPath    string
+	resumed     bool
+
+	mutex      sync.Mutex
+	size       int64
+	etag       string
+	checksum   []byte
+	downloaded int64
+}
+
+// rangeWriter forwards the data to the underlying writer and keeps track of
+// write errors to distinguish them from interrupted connections.
+type rangeWriter struct {
+	writer  io.Writer
+	onWrite func(n int)
+	err     error
+}
+
+func (w *rangeWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.onWrite(n)
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// interruptedError is returned when the connection dropped while reading the
+// response body. The range is requested again from the last received byte.
+type interruptedError struct {
+	err error
+}
+
+func (e interruptedError) Error() string {
+	return fmt.Sprintf("Error downloading file: %v", e.err)
+}
+
+func (e interruptedError) Unwrap() error {
+	return e.err
+}
+
+func (d *RangeDownloader) Download(path string) error {
+	partialPath := path + partialFileExtension
+	d.etagPath = partialPath + etagFileExtension
+	file, err := os.OpenFile(partialPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("Error creating file '%s': %w", partialPath, err)
+	}
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Error reading file '%s': %w", partialPath, err)
+	}
+	if offset > 0 {
+		d.resumed = true
+		d.etag = d.readETag()
+	}
+
+	err = d.download(file, offset)
+	if errors.Is(err, errRestart) {
+		err = d.restart(file)
+	}
+	closeErr := file.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("Error writing file '%s': %w", partialPath, closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	_ = os.Remove(d.etagPath)
+	err = d.verify(partialPath)
+	if err != nil {
+		_ = os.Remove(partialPath)
+		return err
+	}
+	err = os.Rename(partialPath, path)
+	if err != nil {
+		return fmt.Errorf("Error writing file '%s': %w", path, err)
+	}
+	return nil
+}
+
+// IsPartialFile returns true for the .partial and .partial.etag files which
+// are kept next to the destination while the download is not completed.
+func IsPartialFile(path string) bool {
+	return strings.HasSuffix(path, partialFileExtension) || strings.HasSuffix(path, partialFileExtension+etagFileExtension)
+}
+
+// restart discards the partial file and downloads the file from zero.
+func (d *RangeDownloader) restart(file *os.File) error {
+	err := file.Truncate(0)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return fmt.Errorf("Error writing file '%s': %w", file.Name(), err)
+	}
+	_ = os.Remove(d.etagPath)
+	d.resumed = false
+	d.etag = ""
+	d.size = -1
+	d.checksum = nil
+	return d.download(file, 0)
+}
+
+func (d *RangeDownloader) readETag() string {
+	data, err := os.ReadFile(d.etagPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func (d *RangeDownloader) writeETag(etag string) {
+	if d.etagPath != "" {
+		_ = os.WriteFile(d.etagPath, []byte(etag), 0600)
+	}
+}
+
+func (d *RangeDownloader) download(file io.Writer, offset int64) error {
+	d.downloaded = offset
+	if d.parallelism <= 1 {
+		return d.downloadRange(file, offset, -1)
+	}
+
+	end := offset + d.chunkSize - 1
+	err := d.downloadRange(file, offset, end)
+	if err != nil {
+		return err
+	}
+	size := d.size
+	if size < 0 {
+		return d.downloadRange(file, end+1, -1)
+	}
+	for start := end + 1; start < size; start += d.chunkSize * int64(d.parallelism) {
+		err = d.downloadChunks(file, start, size)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *RangeDownloader) downloadChunks(file io.Writer, start int64, size int64) error {
+	buffers := make([]bytes.Buffer, d.parallelism)
+	errs := make([]error, d.parallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < d.parallelism; i++ {
+		chunkStart := start + int64(i)*d.chunkSize
+		if chunkStart >= size {
+			break
+		}
+		chunkEnd := min(chunkStart+d.chunkSize, size) - 1
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = d.downloadRange(&buffers[i], chunkStart, chunkEnd)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range buffers {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		_, err := buffers[i].WriteTo(file)
+		if err != nil {
+			return fmt.Errorf("Error writing file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *RangeDownloader) downloadRange(writer io.Writer, start int64, end int64) error {
+	position := start
+	for attempt := 1; ; attempt++ {
+		written, err := d.fetch(writer, position, end)
+		position += written
+		var interrupted interruptedError
+		if !errors.As(err, &interrupted) {
+			return err
+		}
+		if written > 0 {
+			attempt = 1
+		}
+		if attempt >= d.retry.MaxAttempts {
+			return err
+		}
+		time.Sleep(d.retry.Delay(attempt))
+	}
+}
+
+func (d *RangeDownloader) fetch(writer io.Writer, start int64, end int64) (int64, error) {
+	response, err := d.client.Send(d.createRequest(start, end))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := d.rangeBody(response, start, end)
+	if err != nil {
+		return 0, err
+	}
+	output := &rangeWriter{writer: writer, onWrite: d.updateProgress}
+	written, err := io.Copy(output, body)
+	if output.err != nil {
+		return written, fmt.Errorf("Error writing file: %w", output.err)
+	}
+	if err != nil {
+		return written, interruptedError{err}
+	}
+	expectedEnd := d.expectedEnd(end)
+	if expectedEnd >= 0 && start+written <= expectedEnd {
+		return written, interruptedError{io.ErrUnexpectedEOF}
+	}
+	return written, nil
+}
+
+func (d *RangeDownloader) createRequest(start int64, end int64) *network.HttpRequest {
+	header := http.Header{}
+	if end < 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", start))
+	} else {
+		header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	}
+	etag := d.currentETag()
+	if etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("If-Range", etag)
+	}
+	return network.NewHttpGetRequest(d.url, nil, header)
+}
+
+func (d *RangeDownloader) rangeBody(response *network.HttpResponse, start int64, end int64) (io.Reader, error) {
+	switch response.StatusCode {
+	case http.StatusPartialContent:
+		rangeStart, total, err := d.parseContentRange(response.Header.Get("Content-Range"))
+		if err != nil {
+			return nil, err
+		}
+		if rangeStart != start {
+			return nil, fmt.Errorf("Storage returned range starting at byte %d instead of %d", rangeStart, start)
+		}
+		err = d.update(response, total)
+		if err != nil {
+			return nil, err
+		}
+		return response.Body, nil
+	case http.StatusOK:
+		if d.resumed && start > 0 {
+			return nil, errRestart
+		}
+		err := d.update(response, response.ContentLength)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.CopyN(io.Discard, response.Body, start)
+		if err != nil {
+			return nil, interruptedError{err}
+		}
+		if end >= 0 {
+			return io.LimitReader(response.Body, end-start+1), nil
+		}
+		return response.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		match := unsatisfiedRangePattern.FindStringSubmatch(response.Header.Get("Content-Range"))
+		if match != nil {
+			total, _ := strconv.ParseInt(match[1], 10, 64)
+			if total == start {
+				return http.NoBody, d.update(response, total)
+			}
+		}
+	}
+	body, _ := io.ReadAll(response.Body)
+	return nil, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
+}
+
+func (d *RangeDownloader) parseContentRange(contentRange string) (int64, int64, error) {
+	match := contentRangePattern.FindStringSubmatch(contentRange)
+	if match == nil {
+		return 0, 0, fmt.Errorf("Storage returned invalid Content-Range header '%s'", contentRange)
+	}
+	start, _ := strconv.ParseInt(match[1], 10, 64)
+	total := int64(-1)
+	if match[3] != "*" {
+		total, _ = strconv.ParseInt(match[3], 10, 64)
+	}
+	return start, total, nil
+}
+
+func (d *RangeDownloader) update(response *network.HttpResponse, size int64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	etag := response.Header.Get("ETag")
+	if d.etag != "" && etag != "" && etag != d.etag {
+		return errFileChanged
+	}
+	if d.etag == "" && etag != "" {
+		d.etag = etag
+		d.writeETag(etag)
+	}
+	if size >= 0 {
+		d.size = size
+	}
+	if d.checksum == nil {
+		d.checksum = d.parseChecksum(response)
+	}
+	return nil
+}
+
+func (d *RangeDownloader) parseChecksum(response *network.HttpResponse) []byte {
+	contentMd5 := response.Header.Get("x-ms-blob-content-md5")
+	if contentMd5 == "" && response.StatusCode == http.StatusOK {
+		contentMd5 = response.Header.Get("Content-MD5")
+	}
+	if contentMd5 == "" {
+		return nil
+	}
+	checksum, err := base64.StdEncoding.DecodeString(contentMd5)
+	if err != nil || len(checksum) != md5.Size {
+		return nil
+	}
+	return checksum
+}
+
+func (d *RangeDownloader) currentETag() string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.etag
+}
+
+func (d *RangeDownloader) expectedEnd(end int64) int64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.size < 0 {
+		return end
+	}
+	if end < 0 || end >= d.size {
+		return d.size - 1
+	}
+	return end
+}
+
+func (d *RangeDownloader) updateProgress(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.downloaded += int64(n)
+	if d.progress != nil {
+		d.progress(d.downloaded, d.size)
+	}
+}
+
+func (d *RangeDownloader) verify(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Error reading file '%s': %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := md5.New() //nolint:gosec // MD5 is used by the storage backends to provide the file checksum
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return fmt.Errorf("Error reading file '%s': %w", path, err)
+	}
+	if d.size >= 0 && size != d.size {
+		return fmt.Errorf("Downloaded file size %d does not match the expected size %d", size, d.size)
+	}
+	checksum := hash.Sum(nil)
+	if d.checksum != nil && !bytes.Equal(checksum, d.checksum) {
+		return fmt.Errorf("Downloaded file checksum '%s' does not match the expected checksum '%s'", hex.EncodeToString(checksum), hex.EncodeToString(d.checksum))
+	}
+	return nil
+}
+
+func NewRangeDownloader(client *network.HttpClient, url string, retry resiliency.RetryPolicy, parallelism int, chunkSize int64, progress func(downloaded int64, total int64)) *RangeDownloader {
+	return &RangeDownloader{
+		client:      client,
+		url:         url,
+		retry:       retry,
+		parallelism: parallelism,
+		chunkSize:   chunkSize,
+		progress:    progress,
+		size:        -1,
+	}
+}
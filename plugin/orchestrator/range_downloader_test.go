@@ -0,0 +1,248 @@
+package orchestrator
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/UiPath/uipathcli/log"
+	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/resiliency"
+)
+
+func TestRangeDownloaderFetchesChunksInParallel(t *testing.T) {
+	content := "abcdefghijklmnopqrstuvwxyz"
+	var mutex sync.Mutex
+	ranges := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		ranges[r.Header.Get("Range")] = true
+		mutex.Unlock()
+		w.Header().Set("ETag", `"0x8DC1"`)
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader(content))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 3, 5, nil)
+	err := downloader.Download(path)
+
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Errorf("Expected file content %v, but got: %v", content, string(data))
+	}
+	expected := []string{"bytes=0-4", "bytes=5-9", "bytes=10-14", "bytes=15-19", "bytes=20-24", "bytes=25-25"}
+	for _, r := range expected {
+		if !ranges[r] {
+			t.Errorf("Expected range %v to be requested, but got: %v", r, ranges)
+		}
+	}
+}
+
+func TestRangeDownloaderUsesDefaultFilePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("File permissions are not supported on windows")
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	directory := t.TempDir()
+	reference := filepath.Join(directory, "reference.txt")
+	_ = os.WriteFile(reference, []byte{}, 0644)
+	path := filepath.Join(directory, "file.txt")
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 1, DefaultChunkSize, nil)
+	err := downloader.Download(path)
+
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	expected, _ := os.Stat(reference)
+	actual, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected file to be downloaded, but got: %v", err)
+	}
+	if actual.Mode().Perm() != expected.Mode().Perm() {
+		t.Errorf("Expected file permissions %v, but got: %v", expected.Mode().Perm(), actual.Mode().Perm())
+	}
+}
+
+func TestRangeDownloaderDoesNotVerifyChecksumAgainstETag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"00000000000000000000000000000000"`)
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 1, DefaultChunkSize, nil)
+	err := downloader.Download(path)
+
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected file content hello-world, but got: %v", string(data))
+	}
+}
+
+func TestRangeDownloaderKeepsPartialFileOnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=0-4" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 2, 5, nil)
+	err := downloader.Download(path)
+
+	if err == nil || !strings.Contains(err.Error(), "'403'") {
+		t.Errorf("Expected forbidden error, but got: %v", err)
+	}
+	data, _ := os.ReadFile(path + partialFileExtension)
+	if string(data) != "hello" {
+		t.Errorf("Expected partial file to contain downloaded chunks, but got: %v", string(data))
+	}
+}
+
+func TestRangeDownloaderCompletesFinishedPartialFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	_ = os.WriteFile(path+partialFileExtension, []byte("hello-world"), 0600)
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 1, DefaultChunkSize, nil)
+	err := downloader.Download(path)
+
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected file content, but got: %v", string(data))
+	}
+}
+
+func TestRangeDownloaderFailsWhenFileChanged(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("ETag", `"v1"`)
+		} else {
+			w.Header().Set("ETag", `"v2"`)
+		}
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 2, 5, nil)
+	err := downloader.Download(path)
+
+	if err != errFileChanged {
+		t.Errorf("Expected file changed error, but got: %v", err)
+	}
+}
+
+func TestRangeDownloaderStoresETagNextToPartialFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=0-4" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 2, 5, nil)
+	_ = downloader.Download(path)
+
+	data, _ := os.ReadFile(path + partialFileExtension + etagFileExtension)
+	if string(data) != `"v1"` {
+		t.Errorf("Expected etag file to contain etag, but got: %v", string(data))
+	}
+}
+
+func TestRangeDownloaderResumesWithIfRangeHeader(t *testing.T) {
+	ifRange := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifRange = r.Header.Get("If-Range")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	_ = os.WriteFile(path+partialFileExtension, []byte("hello"), 0600)
+	_ = os.WriteFile(path+partialFileExtension+etagFileExtension, []byte(`"v1"`), 0600)
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 1, DefaultChunkSize, nil)
+	err := downloader.Download(path)
+
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if ifRange != `"v1"` {
+		t.Errorf("Expected stored etag in If-Range header, but got: %v", ifRange)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected file content, but got: %v", string(data))
+	}
+	_, err = os.Stat(path + partialFileExtension + etagFileExtension)
+	if !os.IsNotExist(err) {
+		t.Errorf("Expected etag file to be removed, but got: %v", err)
+	}
+}
+
+func TestRangeDownloaderRestartsWhenFileChangedBeforeResume(t *testing.T) {
+	ranges := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("ETag", `"v2"`)
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader("hello-world"))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	_ = os.WriteFile(path+partialFileExtension, []byte("HELLO"), 0600)
+	_ = os.WriteFile(path+partialFileExtension+etagFileExtension, []byte(`"v1"`), 0600)
+	downloader := NewRangeDownloader(newTestHttpClient(), srv.URL, *resiliency.NewDefaultRetryPolicy(1), 1, DefaultChunkSize, nil)
+	err := downloader.Download(path)
+
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello-world" {
+		t.Errorf("Expected download to restart from zero, but got: %v", string(data))
+	}
+	if len(ranges) != 2 || ranges[0] != "bytes=5-" || ranges[1] != "bytes=0-" {
+		t.Errorf("Expected download to be restarted, but got ranges: %v", ranges)
+	}
+}
+
+func newTestHttpClient() *network.HttpClient {
+	settings := network.NewHttpClientSettings(false, "", map[string]string{}, 0, *resiliency.NewDefaultRetryPolicy(1), false, network.RateLimitSettings{}, network.ConnectionSettings{}, nil, false)
+	return network.NewHttpClient(log.NewDefaultLogger(io.Discard), *settings)
+}
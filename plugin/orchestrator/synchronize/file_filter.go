@@ -0,0 +1,62 @@
+package synchronize
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// The fileFilter decides which files are synchronized based on include and
+// exclude glob patterns.
+//
+// A pattern matches a file when it matches the relative path of the file, its
+// file name or one of its parent directories. Files are synchronized when they
+// match any include pattern (or no include patterns are provided) and do not
+// match any of the exclude patterns.
+type fileFilter struct {
+	include []string
+	exclude []string
+}
+
+func (f fileFilter) Validate() error {
+	for _, pattern := range append(f.include, f.exclude...) {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("Invalid glob pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+func (f fileFilter) Matches(relativePath string) bool {
+	if len(f.include) > 0 && !f.matchesAny(f.include, relativePath) {
+		return false
+	}
+	return !f.matchesAny(f.exclude, relativePath)
+}
+
+func (f fileFilter) matchesAny(patterns []string, relativePath string) bool {
+	for _, pattern := range patterns {
+		if f.matches(pattern, relativePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fileFilter) matches(pattern string, relativePath string) bool {
+	pattern = strings.Trim(pattern, "/")
+	if matched, _ := path.Match(pattern, path.Base(relativePath)); matched {
+		return true
+	}
+	for current := relativePath; current != "." && current != "/"; current = path.Dir(current) {
+		if matched, _ := path.Match(pattern, current); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func newFileFilter(include []string, exclude []string) *fileFilter {
+	return &fileFilter{include, exclude}
+}
@@ -0,0 +1,54 @@
+package synchronize
+
+import "testing"
+
+func TestFileFilterWithoutPatternsMatchesAllFiles(t *testing.T) {
+	filter := newFileFilter([]string{}, []string{})
+
+	if !filter.Matches("folder/file.txt") {
+		t.Errorf("Expected file to match")
+	}
+}
+
+func TestFileFilterIncludeMatchesFileName(t *testing.T) {
+	filter := newFileFilter([]string{"*.pdf"}, []string{})
+
+	if !filter.Matches("invoices/2024/invoice.pdf") {
+		t.Errorf("Expected pdf file to match")
+	}
+	if filter.Matches("invoices/2024/invoice.png") {
+		t.Errorf("Expected png file not to match")
+	}
+}
+
+func TestFileFilterExcludeMatchesParentFolder(t *testing.T) {
+	filter := newFileFilter([]string{}, []string{"tmp"})
+
+	if filter.Matches("tmp/cache/file.txt") {
+		t.Errorf("Expected file in excluded folder not to match")
+	}
+	if !filter.Matches("src/file.txt") {
+		t.Errorf("Expected file outside of excluded folder to match")
+	}
+}
+
+func TestFileFilterMatchesRelativePath(t *testing.T) {
+	filter := newFileFilter([]string{"invoices/*/*.pdf"}, []string{})
+
+	if !filter.Matches("invoices/2024/invoice.pdf") {
+		t.Errorf("Expected file to match relative path pattern")
+	}
+	if filter.Matches("receipts/2024/receipt.pdf") {
+		t.Errorf("Expected file in other folder not to match")
+	}
+}
+
+func TestFileFilterInvalidPatternReturnsError(t *testing.T) {
+	filter := newFileFilter([]string{"[invalid"}, []string{})
+
+	err := filter.Validate()
+
+	if err == nil || err.Error() != "Invalid glob pattern '[invalid'" {
+		t.Errorf("Expected invalid pattern error, but got: %v", err)
+	}
+}
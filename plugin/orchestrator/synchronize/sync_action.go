@@ -0,0 +1,17 @@
+package synchronize
+
+import "time"
+
+// The syncAction describes a single operation which needs to be performed to
+// synchronize a file between the local folder and the bucket.
+type syncAction struct {
+	Action       string
+	Path         string
+	File         string
+	Size         int64
+	LastModified time.Time
+}
+
+func newSyncAction(action string, path string, file string, size int64, lastModified time.Time) *syncAction {
+	return &syncAction{action, path, file, size, lastModified}
+}
@@ -0,0 +1,414 @@
+// Package synchronize implements a plugin for synchronizing local folders with
+// orchestrator buckets. It compares both sides and only transfers the files
+// which differ.
+package synchronize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/UiPath/uipathcli/log"
+	"github.com/UiPath/uipathcli/output"
+	"github.com/UiPath/uipathcli/plugin"
+	"github.com/UiPath/uipathcli/plugin/orchestrator"
+	"github.com/UiPath/uipathcli/utils/api"
+	"github.com/UiPath/uipathcli/utils/network"
+	"github.com/UiPath/uipathcli/utils/stream"
+	"github.com/UiPath/uipathcli/utils/visualization"
+)
+
+const defaultParallelism = 4
+
+// The SyncCommand is a custom command for the orchestrator service which synchronizes
+// a local folder with a bucket. Files are uploaded when --source is provided and
+// downloaded when --destination is provided.
+//
+// The remote files are listed using the ListFiles API and compared with the local
+// files by size and modification time. Only the differences are transferred in
+// parallel. The --delete flag removes files from the target which do not exist in the
+// source anymore.
+type SyncCommand struct{}
+
+type localFile struct {
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+func (c SyncCommand) Command() plugin.Command {
+	return *plugin.NewCommand("orchestrator").
+		WithCategory("buckets", "Orchestrator Buckets", "Buckets provide a per-folder storage solution for RPA developers to leverage in creating automation projects.").
+		WithOperation("sync", "Sync folder", "Synchronizes a local folder with the files in the bucket").
+		WithDryRun(true).
+		WithParameter(plugin.NewParameter("folder-id", plugin.ParameterTypeInteger, "Folder/OrganizationUnit Id").
+			WithRequired(true)).
+		WithParameter(plugin.NewParameter("key", plugin.ParameterTypeInteger, "The Bucket Id").
+			WithRequired(true)).
+		WithParameter(plugin.NewParameter("path", plugin.ParameterTypeString, "The remote path prefix in the bucket")).
+		WithParameter(plugin.NewParameter("source", plugin.ParameterTypeString, "The local folder to upload to the bucket")).
+		WithParameter(plugin.NewParameter("destination", plugin.ParameterTypeString, "The local folder to download the bucket files to")).
+		WithParameter(plugin.NewParameter("delete", plugin.ParameterTypeBoolean, "Delete files which do not exist in the source")).
+		WithParameter(plugin.NewParameter("include", plugin.ParameterTypeStringArray, "Only sync files matching the glob pattern")).
+		WithParameter(plugin.NewParameter("exclude", plugin.ParameterTypeStringArray, "Skip files matching the glob pattern")).
+		WithParameter(plugin.NewParameter("parallelism", plugin.ParameterTypeInteger, "The number of files to transfer in parallel (default: 4)"))
+}
+
+func (c SyncCommand) Execute(ctx plugin.ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	if ctx.Organization == "" {
+		return errors.New("Organization is not set")
+	}
+	if ctx.Tenant == "" {
+		return errors.New("Tenant is not set")
+	}
+	params, err := c.getParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewOrchestratorClient(ctx.BaseUri, ctx.Organization, ctx.Tenant, ctx.Auth.Token, ctx.Debug, ctx.Settings, logger)
+	actions, err := c.plan(*params, client)
+	if err != nil {
+		return err
+	}
+
+	var results []syncResult
+	if ctx.DryRun {
+		results = c.planned(actions)
+	} else {
+		results = c.execute(ctx, *params, client, actions, logger)
+	}
+
+	json, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("Sync command failed: %w", err)
+	}
+	err = writer.WriteResponse(*output.NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewReader(json)))
+	if err != nil {
+		return err
+	}
+	failed := c.countFailed(results)
+	if failed > 0 {
+		return fmt.Errorf("Failed to sync %d of %d files", failed, len(results))
+	}
+	return nil
+}
+
+func (c SyncCommand) plan(params syncParams, client *api.OrchestratorClient) ([]syncAction, error) {
+	bucketFiles, err := client.ListBucketFiles(params.FolderId, params.BucketId, params.Path)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, err := c.remoteFiles(params, bucketFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	directory := params.Source
+	if directory == "" {
+		directory = params.Destination
+	}
+	localFiles, err := c.localFiles(params, directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []syncAction
+	if params.Source != "" {
+		actions = c.planUpload(params, localFiles, remoteFiles)
+	} else {
+		actions = c.planDownload(params, localFiles, remoteFiles)
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Path+actions[i].File < actions[j].Path+actions[j].File
+	})
+	return actions, nil
+}
+
+func (c SyncCommand) planUpload(params syncParams, localFiles map[string]localFile, remoteFiles map[string]api.BucketFile) []syncAction {
+	actions := []syncAction{}
+	for relativePath, local := range localFiles {
+		remote, found := remoteFiles[relativePath]
+		if !found || remote.Size != local.Size || local.LastModified.After(remote.LastModified) {
+			actions = append(actions, *newSyncAction(syncActionUpload, c.remotePath(params, relativePath), local.Path, local.Size, local.LastModified))
+		}
+	}
+	if params.Delete {
+		for relativePath, remote := range remoteFiles {
+			if _, found := localFiles[relativePath]; !found {
+				actions = append(actions, *newSyncAction(syncActionDelete, remote.FullPath, "", remote.Size, remote.LastModified))
+			}
+		}
+	}
+	return actions
+}
+
+func (c SyncCommand) planDownload(params syncParams, localFiles map[string]localFile, remoteFiles map[string]api.BucketFile) []syncAction {
+	actions := []syncAction{}
+	for relativePath, remote := range remoteFiles {
+		local, found := localFiles[relativePath]
+		if !found || remote.Size != local.Size || remote.LastModified.After(local.LastModified) {
+			file := filepath.Join(params.Destination, filepath.FromSlash(relativePath))
+			actions = append(actions, *newSyncAction(syncActionDownload, remote.FullPath, file, remote.Size, remote.LastModified))
+		}
+	}
+	if params.Delete {
+		for relativePath, local := range localFiles {
+			if _, found := remoteFiles[relativePath]; !found {
+				actions = append(actions, *newSyncAction(syncActionDelete, "", local.Path, local.Size, local.LastModified))
+			}
+		}
+	}
+	return actions
+}
+
+// remoteFiles returns the bucket files by their path relative to the --path
+// prefix. The paths are provided by the service and are rejected in case they
+// would resolve to a file outside of the destination folder, e.g. x/../../file.
+func (c SyncCommand) remoteFiles(params syncParams, bucketFiles []api.BucketFile) (map[string]api.BucketFile, error) {
+	result := map[string]api.BucketFile{}
+	for _, file := range bucketFiles {
+		fullPath := strings.Trim(file.FullPath, "/")
+		relativePath := fullPath
+		if params.Path != "" {
+			if !strings.HasPrefix(fullPath, params.Path+"/") {
+				continue
+			}
+			relativePath = strings.TrimPrefix(fullPath, params.Path+"/")
+		}
+		if params.Destination != "" && !c.isInsideDirectory(params.Destination, relativePath) {
+			return nil, fmt.Errorf("Remote file '%s' resolves to a path outside of the destination folder", file.FullPath)
+		}
+		if params.Filter.Matches(relativePath) {
+			result[relativePath] = file
+		}
+	}
+	return result, nil
+}
+
+func (c SyncCommand) isInsideDirectory(directory string, relativePath string) bool {
+	base := filepath.Clean(directory)
+	file := filepath.Join(base, filepath.FromSlash(relativePath))
+	relative, err := filepath.Rel(base, file)
+	if err != nil {
+		return false
+	}
+	return filepath.IsLocal(relative) && filepath.IsLocal(filepath.FromSlash(relativePath))
+}
+
+func (c SyncCommand) localFiles(params syncParams, directory string) (map[string]localFile, error) {
+	result := map[string]localFile{}
+	if params.Destination != "" {
+		if _, err := os.Stat(directory); errors.Is(err, os.ErrNotExist) {
+			return result, nil
+		}
+	}
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (params.Destination != "" && orchestrator.IsPartialFile(filePath)) {
+			return nil
+		}
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if !params.Filter.Matches(relativePath) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		result[relativePath] = localFile{filePath, info.Size(), info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading folder '%s': %w", directory, err)
+	}
+	return result, nil
+}
+
+func (c SyncCommand) remotePath(params syncParams, relativePath string) string {
+	if params.Path == "" {
+		return relativePath
+	}
+	return params.Path + "/" + relativePath
+}
+
+func (c SyncCommand) planned(actions []syncAction) []syncResult {
+	results := []syncResult{}
+	for _, action := range actions {
+		results = append(results, *newPlannedSyncResult(action))
+	}
+	return results
+}
+
+func (c SyncCommand) execute(ctx plugin.ExecutionContext, params syncParams, client *api.OrchestratorClient, actions []syncAction, logger log.Logger) []syncResult {
+	results := make([]syncResult, len(actions))
+	if len(actions) == 0 {
+		return results
+	}
+
+	progressBar := visualization.NewProgressBar(logger)
+	defer progressBar.Remove()
+	var mutex sync.Mutex
+	completed := 0
+	progressBar.UpdateSteps("syncing...", completed, len(actions))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(params.Parallelism, len(actions)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := c.executeAction(ctx, params, client, actions[i], logger)
+				if err != nil {
+					results[i] = *newFailedSyncResult(actions[i], err.Error())
+				} else {
+					results[i] = *newSucceededSyncResult(actions[i])
+				}
+				mutex.Lock()
+				completed++
+				progressBar.UpdateSteps("syncing...", completed, len(actions))
+				mutex.Unlock()
+			}
+		}()
+	}
+	for i := range actions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func (c SyncCommand) executeAction(ctx plugin.ExecutionContext, params syncParams, client *api.OrchestratorClient, action syncAction, logger log.Logger) error {
+	switch {
+	case action.Action == syncActionUpload:
+		return c.upload(ctx, params, client, action, logger)
+	case action.Action == syncActionDownload:
+		return c.download(ctx, params, client, action, logger)
+	case action.Action == syncActionDelete && params.Source != "":
+		return client.DeleteBucketFile(params.FolderId, params.BucketId, action.Path)
+	default:
+		err := os.Remove(action.File)
+		if err != nil {
+			return fmt.Errorf("Error deleting file '%s': %w", action.File, err)
+		}
+		return nil
+	}
+}
+
+func (c SyncCommand) upload(ctx plugin.ExecutionContext, params syncParams, client *api.OrchestratorClient, action syncAction, logger log.Logger) error {
+	url, err := client.GetWriteUrl(params.FolderId, params.BucketId, action.Path)
+	if err != nil {
+		return err
+	}
+	httpClient := network.NewHttpClient(logger, c.httpClientSettings(ctx))
+	uploader := orchestrator.NewBlobUploader(httpClient)
+	return uploader.Upload(url, stream.NewFileStream(action.File), func(reader io.Reader, length int64) io.Reader {
+		return reader
+	})
+}
+
+func (c SyncCommand) download(ctx plugin.ExecutionContext, params syncParams, client *api.OrchestratorClient, action syncAction, logger log.Logger) error {
+	url, err := client.GetReadUrl(params.FolderId, params.BucketId, action.Path)
+	if err != nil {
+		return err
+	}
+	directory := filepath.Dir(action.File)
+	err = os.MkdirAll(directory, 0755)
+	if err != nil {
+		return fmt.Errorf("Error creating folder '%s': %w", directory, err)
+	}
+	httpClient := network.NewHttpClient(logger, c.httpClientSettings(ctx))
+	downloader := orchestrator.NewRangeDownloader(httpClient, url, ctx.Settings.Retry, 1, orchestrator.DefaultChunkSize, nil)
+	err = downloader.Download(action.File)
+	if err != nil {
+		return err
+	}
+	if !action.LastModified.IsZero() {
+		_ = os.Chtimes(action.File, action.LastModified, action.LastModified)
+	}
+	return nil
+}
+
+func (c SyncCommand) countFailed(results []syncResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Error != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (c SyncCommand) getParams(ctx plugin.ExecutionContext) (*syncParams, error) {
+	source := ctx.Parameters.String("source", "")
+	destination := ctx.Parameters.String("destination", "")
+	if source == "" && destination == "" {
+		return nil, errors.New("Either --source or --destination needs to be provided")
+	}
+	if source != "" && destination != "" {
+		return nil, errors.New("Only one of --source or --destination can be provided")
+	}
+	if source != "" {
+		info, err := os.Stat(source)
+		if err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("Source folder '%s' not found", source)
+		}
+	}
+	parallelism := ctx.Parameters.Int("parallelism", defaultParallelism)
+	if parallelism < 1 {
+		return nil, fmt.Errorf("Invalid value for 'parallelism': %d", parallelism)
+	}
+	filter := newFileFilter(
+		ctx.Parameters.StringArray("include"),
+		ctx.Parameters.StringArray("exclude"))
+	err := filter.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return newSyncParams(
+		ctx.Parameters.Int("folder-id", 0),
+		ctx.Parameters.Int("key", 0),
+		strings.Trim(path.Clean("/"+ctx.Parameters.String("path", "")), "/"),
+		source,
+		destination,
+		ctx.Parameters.Bool("delete", false),
+		*filter,
+		parallelism), nil
+}
+
+func (c SyncCommand) httpClientSettings(ctx plugin.ExecutionContext) network.HttpClientSettings {
+	return *network.NewHttpClientSettings(
+		ctx.Debug,
+		ctx.Settings.OperationId,
+		ctx.Settings.Header,
+		ctx.Settings.Timeout,
+		ctx.Settings.Retry,
+		ctx.Settings.RetryNonIdempotent,
+		ctx.Settings.RateLimit,
+		ctx.Settings.Connection,
+		ctx.Settings.Tracer,
+		ctx.Settings.Insecure)
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{}
+}
@@ -0,0 +1,425 @@
+package synchronize
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/UiPath/uipathcli/test"
+)
+
+const syncDefinition = `
+servers:
+- url: https://cloud.uipath.com/{organization}/{tenant}/orchestrator_
+  description: The production url
+  variables:
+    organization:
+      description: The organization name (or id)
+      default: my-org
+    tenant:
+      description: The tenant name (or id)
+      default: my-tenant
+`
+
+type storageServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	uploaded map[string]string
+	files    map[string]string
+}
+
+func (s *storageServer) Uploaded() map[string]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.uploaded
+}
+
+func newStorageServer(files map[string]string) *storageServer {
+	storage := &storageServer{uploaded: map[string]string{}, files: files}
+	storage.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			storage.mutex.Lock()
+			storage.uploaded[path] = string(body)
+			storage.mutex.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(storage.files[path]))
+	}))
+	return storage
+}
+
+func orchestratorHandler(storageUrl string, listResponse string, deleted *[]string) func(test.RequestData) test.ResponseData {
+	return func(request test.RequestData) test.ResponseData {
+		path := request.URL.Query().Get("path")
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/ListFiles"):
+			return test.ResponseData{Status: http.StatusOK, Body: listResponse}
+		case strings.HasSuffix(request.URL.Path, "GetWriteUri"), strings.HasSuffix(request.URL.Path, "GetReadUri"):
+			return test.ResponseData{Status: http.StatusOK, Body: `{"Uri":"` + storageUrl + `/` + path + `"}`}
+		case strings.HasSuffix(request.URL.Path, "DeleteFile"):
+			*deleted = append(*deleted, path)
+			return test.ResponseData{Status: http.StatusNoContent}
+		}
+		return test.ResponseData{Status: http.StatusNotFound}
+	}
+}
+
+func createFile(t *testing.T, path string, content string, lastModified time.Time) {
+	_ = os.MkdirAll(filepath.Dir(path), 0700)
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Chtimes(path, lastModified, lastModified)
+}
+
+func TestSyncWithoutSourceAndDestinationShowsValidationError(t *testing.T) {
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2"}, context)
+
+	if !strings.Contains(result.StdErr, "Either --source or --destination needs to be provided") {
+		t.Errorf("Expected stderr to show validation error, but got: %v", result.StdErr)
+	}
+}
+
+func TestSyncUploadsNewAndChangedFiles(t *testing.T) {
+	storage := newStorageServer(map[string]string{})
+	defer storage.Close()
+
+	source := t.TempDir()
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	createFile(t, filepath.Join(source, "new.txt"), "new", past)
+	createFile(t, filepath.Join(source, "unchanged.txt"), "same", past)
+	createFile(t, filepath.Join(source, "sub", "changed.txt"), "changed", past)
+
+	listResponse := `{"items":[
+  {"fullPath":"remote/unchanged.txt","size":4,"lastModified":"2024-01-01T00:00:00Z"},
+  {"fullPath":"remote/sub/changed.txt","size":1,"lastModified":"2024-01-01T00:00:00Z"}
+]}`
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, listResponse, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--source", source, "--path", "remote"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	uploaded := storage.Uploaded()
+	if len(uploaded) != 2 || uploaded["remote/new.txt"] != "new" || uploaded["remote/sub/changed.txt"] != "changed" {
+		t.Errorf("Expected new and changed files to be uploaded, but got: %v", uploaded)
+	}
+	expected := `[
+  {
+    "action": "Upload",
+    "error": null,
+    "file": "` + filepath.Join(source, "new.txt") + `",
+    "path": "remote/new.txt",
+    "size": 3,
+    "status": "Succeeded"
+  },
+  {
+    "action": "Upload",
+    "error": null,
+    "file": "` + filepath.Join(source, "sub", "changed.txt") + `",
+    "path": "remote/sub/changed.txt",
+    "size": 7,
+    "status": "Succeeded"
+  }
+]
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected sync results on stdout, but got: %v", result.StdOut)
+	}
+}
+
+func TestSyncDeletesRemoteFilesWithDeleteFlag(t *testing.T) {
+	storage := newStorageServer(map[string]string{})
+	defer storage.Close()
+
+	source := t.TempDir()
+	createFile(t, filepath.Join(source, "keep.txt"), "keep", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	listResponse := `{"items":[
+  {"fullPath":"keep.txt","size":4,"lastModified":"2024-01-01T00:00:00Z"},
+  {"fullPath":"removed.txt","size":1,"lastModified":"2024-01-01T00:00:00Z"}
+]}`
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, listResponse, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--source", source, "--delete", "true"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if len(deleted) != 1 || deleted[0] != "removed.txt" {
+		t.Errorf("Expected removed file to be deleted from bucket, but got: %v", deleted)
+	}
+	if len(storage.Uploaded()) != 0 {
+		t.Errorf("Expected no uploads, but got: %v", storage.Uploaded())
+	}
+}
+
+func TestSyncDownloadsRemoteFiles(t *testing.T) {
+	storage := newStorageServer(map[string]string{
+		"remote/a.txt":     "hello",
+		"remote/sub/b.txt": "world",
+	})
+	defer storage.Close()
+
+	destination := t.TempDir()
+	createFile(t, filepath.Join(destination, "obsolete.txt"), "old", time.Now())
+
+	listResponse := `{"items":[
+  {"fullPath":"remote/a.txt","size":5,"lastModified":"2024-01-01T00:00:00Z"},
+  {"fullPath":"remote/sub/b.txt","size":5,"lastModified":"2024-01-01T00:00:00Z"}
+]}`
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, listResponse, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "remote", "--destination", destination, "--delete", "true"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	data, _ := os.ReadFile(filepath.Join(destination, "sub", "b.txt"))
+	if string(data) != "world" {
+		t.Errorf("Expected file to be downloaded, but got: %v", string(data))
+	}
+	info, _ := os.Stat(filepath.Join(destination, "a.txt"))
+	if info == nil || !info.ModTime().Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected modification time to match the remote file, but got: %v", info)
+	}
+	if _, err := os.Stat(filepath.Join(destination, "obsolete.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected obsolete file to be deleted, but got: %v", err)
+	}
+}
+
+func TestSyncDownloadKeepsPartialFiles(t *testing.T) {
+	storage := newStorageServer(map[string]string{"a.txt": "hello"})
+	defer storage.Close()
+
+	destination := t.TempDir()
+	createFile(t, filepath.Join(destination, "b.txt.partial"), "par", time.Now())
+
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, `{"items":[{"fullPath":"a.txt","size":5}]}`, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--destination", destination, "--delete", "true"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if _, err := os.Stat(filepath.Join(destination, "b.txt.partial")); err != nil {
+		t.Errorf("Expected partial file to be kept, but got: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(destination, "a.txt"))
+	if string(data) != "hello" {
+		t.Errorf("Expected file to be downloaded, but got: %v", string(data))
+	}
+}
+
+func TestSyncDownloadUsesDefaultFilePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("File permissions are not supported on windows")
+	}
+	storage := newStorageServer(map[string]string{"a.txt": "hello"})
+	defer storage.Close()
+
+	destination := t.TempDir()
+	reference := filepath.Join(t.TempDir(), "reference.txt")
+	_ = os.WriteFile(reference, []byte{}, 0644)
+
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, `{"items":[{"fullPath":"a.txt","size":5}]}`, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--destination", destination}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	expected, _ := os.Stat(reference)
+	actual, err := os.Stat(filepath.Join(destination, "a.txt"))
+	if err != nil {
+		t.Fatalf("Expected file to be downloaded, but got: %v", err)
+	}
+	if actual.Mode().Perm() != expected.Mode().Perm() {
+		t.Errorf("Expected file permissions %v, but got: %v", expected.Mode().Perm(), actual.Mode().Perm())
+	}
+}
+
+func TestSyncUploadRequiresCreatedResponse(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer storage.Close()
+
+	source := t.TempDir()
+	createFile(t, filepath.Join(source, "a.txt"), "hello", time.Now())
+
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, `{"items":[]}`, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--source", source}, context)
+
+	if result.Error == nil || result.Error.Error() != "Failed to sync 1 of 1 files" {
+		t.Errorf("Expected sync to fail, but got: %v", result.Error)
+	}
+	if !strings.Contains(result.StdOut, "Orchestrator returned status code '200'") {
+		t.Errorf("Expected upload error on stdout, but got: %v", result.StdOut)
+	}
+}
+
+func TestSyncDryRunOutputsPlan(t *testing.T) {
+	storage := newStorageServer(map[string]string{})
+	defer storage.Close()
+
+	source := t.TempDir()
+	createFile(t, filepath.Join(source, "new.txt"), "new", time.Now())
+
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, `{"items":[{"fullPath":"old.txt","size":1}]}`, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--source", source, "--delete", "true", "--dry-run"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if len(storage.Uploaded()) != 0 || len(deleted) != 0 {
+		t.Errorf("Expected dry run not to change any files, but got uploads: %v, deletes: %v", storage.Uploaded(), deleted)
+	}
+	if !strings.Contains(result.StdOut, `"action": "Upload"`) || !strings.Contains(result.StdOut, `"action": "Delete"`) || strings.Count(result.StdOut, `"status": "Planned"`) != 2 {
+		t.Errorf("Expected planned actions on stdout, but got: %v", result.StdOut)
+	}
+}
+
+func TestSyncSkipsExcludedFiles(t *testing.T) {
+	storage := newStorageServer(map[string]string{})
+	defer storage.Close()
+
+	source := t.TempDir()
+	createFile(t, filepath.Join(source, "invoice.pdf"), "pdf", time.Now())
+	createFile(t, filepath.Join(source, "debug.log"), "log", time.Now())
+	createFile(t, filepath.Join(source, "tmp", "invoice.pdf"), "tmp", time.Now())
+
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, `{"items":[{"fullPath":"notes.txt","size":1}]}`, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--source", source, "--include", "*.pdf", "--exclude", "tmp", "--delete", "true"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	uploaded := storage.Uploaded()
+	if len(uploaded) != 1 || uploaded["invoice.pdf"] != "pdf" {
+		t.Errorf("Expected only included files to be uploaded, but got: %v", uploaded)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected filtered remote files not to be deleted, but got: %v", deleted)
+	}
+}
+
+func TestSyncReturnsErrorWhenTransferFails(t *testing.T) {
+	source := t.TempDir()
+	createFile(t, filepath.Join(source, "new.txt"), "new", time.Now())
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(func(request test.RequestData) test.ResponseData {
+			if strings.HasSuffix(request.URL.Path, "/ListFiles") {
+				return test.ResponseData{Status: http.StatusOK, Body: `{"items":[]}`}
+			}
+			return test.ResponseData{Status: http.StatusForbidden, Body: "forbidden"}
+		}).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--source", source}, context)
+
+	if result.Error == nil || result.Error.Error() != "Failed to sync 1 of 1 files" {
+		t.Errorf("Expected sync error, but got: %v", result.Error)
+	}
+	if !strings.Contains(result.StdOut, `"status": "Failed"`) {
+		t.Errorf("Expected failed result on stdout, but got: %v", result.StdOut)
+	}
+}
+
+func TestSyncDownloadRejectsRemotePathOutsideOfDestination(t *testing.T) {
+	storage := newStorageServer(map[string]string{
+		"remote/x/../../../outside.txt": "malicious",
+	})
+	defer storage.Close()
+
+	parent := t.TempDir()
+	destination := filepath.Join(parent, "target", "folder")
+	createFile(t, filepath.Join(destination, "keep.txt"), "keep", time.Now())
+
+	listResponse := `{"items":[
+  {"fullPath":"remote/x/../../../outside.txt","size":9,"lastModified":"2024-01-01T00:00:00Z"}
+]}`
+	deleted := []string{}
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", syncDefinition).
+		WithCommandPlugin(NewSyncCommand()).
+		WithResponseHandler(orchestratorHandler(storage.URL, listResponse, &deleted)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "sync", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "remote", "--destination", destination, "--delete", "true"}, context)
+
+	expected := "Remote file 'remote/x/../../../outside.txt' resolves to a path outside of the destination folder"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected error %v, but got: %v", expected, result.Error)
+	}
+	if _, err := os.Stat(filepath.Join(parent, "outside.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be written outside of the destination folder, but got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destination, "keep.txt")); err != nil {
+		t.Errorf("Expected local files not to be deleted, but got: %v", err)
+	}
+}
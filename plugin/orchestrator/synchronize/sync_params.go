@@ -0,0 +1,33 @@
+package synchronize
+
+type syncParams struct {
+	FolderId    int
+	BucketId    int
+	Path        string
+	Source      string
+	Destination string
+	Delete      bool
+	Filter      fileFilter
+	Parallelism int
+}
+
+func newSyncParams(
+	folderId int,
+	bucketId int,
+	path string,
+	source string,
+	destination string,
+	deleteFiles bool,
+	filter fileFilter,
+	parallelism int) *syncParams {
+	return &syncParams{
+		folderId,
+		bucketId,
+		path,
+		source,
+		destination,
+		deleteFiles,
+		filter,
+		parallelism,
+	}
+}
@@ -0,0 +1,28 @@
+package synchronize
+
+const (
+	syncActionUpload   = "Upload"
+	syncActionDownload = "Download"
+	syncActionDelete   = "Delete"
+)
+
+type syncResult struct {
+	Action string  `json:"action"`
+	Path   string  `json:"path"`
+	File   string  `json:"file"`
+	Size   int64   `json:"size"`
+	Status string  `json:"status"`
+	Error  *string `json:"error"`
+}
+
+func newPlannedSyncResult(action syncAction) *syncResult {
+	return &syncResult{action.Action, action.Path, action.File, action.Size, "Planned", nil}
+}
+
+func newSucceededSyncResult(action syncAction) *syncResult {
+	return &syncResult{action.Action, action.Path, action.File, action.Size, "Succeeded", nil}
+}
+
+func newFailedSyncResult(action syncAction, err string) *syncResult {
+	return &syncResult{action.Action, action.Path, action.File, action.Size, "Failed", &err}
+}
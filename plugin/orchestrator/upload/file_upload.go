@@ -0,0 +1,14 @@
+package upload
+
+import "github.com/UiPath/uipathcli/utils/stream"
+
+// The fileUpload contains the local file and the remote path in the bucket
+// it is uploaded to.
+type fileUpload struct {
+	File stream.Stream
+	Path string
+}
+
+func newFileUpload(file stream.Stream, path string) *fileUpload {
+	return &fileUpload{file, path}
+}
@@ -4,15 +4,22 @@
 package upload
 
 import (
-	"context"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/UiPath/uipathcli/log"
 	"github.com/UiPath/uipathcli/output"
 	"github.com/UiPath/uipathcli/plugin"
+	"github.com/UiPath/uipathcli/plugin/orchestrator"
 	"github.com/UiPath/uipathcli/utils/api"
 	"github.com/UiPath/uipathcli/utils/network"
 	"github.com/UiPath/uipathcli/utils/stream"
@@ -22,8 +29,14 @@ import (
 // The UploadCommand is a custom command for the orchestrator service which makes uploading
 // files more convenient. It provides a wrapper over retrieving the write url and actually
 // performing the upload.
+//
+// Multiple files or glob patterns can be uploaded at once. In this case the --path
+// argument is used as a prefix for the remote paths and the files are uploaded in
+// parallel.
 type UploadCommand struct{}
 
+const defaultParallelism = 4
+
 func (c UploadCommand) Command() plugin.Command {
 	return *plugin.NewCommand("orchestrator").
 		WithCategory("buckets", "Orchestrator Buckets", "Buckets provide a per-folder storage solution for RPA developers to leverage in creating automation projects.").
@@ -34,72 +47,203 @@ func (c UploadCommand) Command() plugin.Command {
 			WithRequired(true)).
 		WithParameter(plugin.NewParameter("path", plugin.ParameterTypeString, "The BlobFile full path").
 			WithRequired(true)).
-		WithParameter(plugin.NewParameter("file", plugin.ParameterTypeBinary, "The file to upload").
-			WithRequired(true))
+		WithParameter(plugin.NewParameter("file", plugin.ParameterTypeBinaryArray, "The file to upload, multiple files or glob patterns like invoices/*.pdf are uploaded to the --path prefix").
+			WithRequired(true)).
+		WithParameter(plugin.NewParameter("parallelism", plugin.ParameterTypeInteger, "The number of files to upload in parallel (default: 4)"))
 }
 
 func (c UploadCommand) Execute(ctx plugin.ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
-	writeUrl, err := c.getWriteUrl(ctx, logger)
+	if ctx.Organization == "" {
+		return errors.New("Organization is not set")
+	}
+	if ctx.Tenant == "" {
+		return errors.New("Tenant is not set")
+	}
+	path := c.getStringParameter("path", ctx.Parameters)
+	uploads, multiple, err := c.expandFiles(c.getFiles(ctx), path)
 	if err != nil {
 		return err
 	}
-	return c.upload(ctx, logger, writeUrl)
+	if multiple {
+		return c.uploadFiles(ctx, writer, logger, uploads)
+	}
+
+	writeUrl, err := c.getWriteUrl(ctx, logger, path)
+	if err != nil {
+		return err
+	}
+	uploadBar := visualization.NewProgressBar(logger)
+	defer uploadBar.Remove()
+	return c.upload(ctx, logger, writeUrl, uploads[0].File, func(reader io.Reader, length int64) io.Reader {
+		return c.progressReader("uploading...", "completing  ", reader, length, uploadBar)
+	})
 }
 
-func (c UploadCommand) upload(ctx plugin.ExecutionContext, logger log.Logger, url string) error {
+func (c UploadCommand) uploadFiles(ctx plugin.ExecutionContext, writer output.OutputWriter, logger log.Logger, uploads []fileUpload) error {
+	parallelism := c.getIntParameter("parallelism", defaultParallelism, ctx.Parameters)
+	if parallelism < 1 {
+		return fmt.Errorf("Invalid value for 'parallelism': %d", parallelism)
+	}
+	folderId := c.getIntParameter("folder-id", 0, ctx.Parameters)
+	bucketId := c.getIntParameter("key", 0, ctx.Parameters)
+	client := api.NewOrchestratorClient(ctx.BaseUri, ctx.Organization, ctx.Tenant, ctx.Auth.Token, ctx.Debug, ctx.Settings, logger)
+
 	uploadBar := visualization.NewProgressBar(logger)
 	defer uploadBar.Remove()
-	context, cancel := context.WithCancelCause(context.Background())
-	request := c.createUploadRequest(ctx, url, uploadBar, cancel)
-	client := network.NewHttpClient(logger, c.httpClientSettings(ctx))
-	response, err := client.SendWithContext(request, context)
+	progress := newUploadProgress(uploadBar, c.totalSize(uploads))
+
+	results := make([]uploadResult, len(uploads))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parallelism, len(uploads)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := c.uploadFile(ctx, logger, client, folderId, bucketId, uploads[i], progress)
+				if err != nil {
+					results[i] = *newFailedUploadResult(uploads[i], err.Error())
+				} else {
+					results[i] = *newSucceededUploadResult(uploads[i])
+				}
+			}
+		}()
+	}
+	for i := range uploads {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	uploadBar.Remove()
+
+	json, err := json.Marshal(results)
 	if err != nil {
-		return err
+		return fmt.Errorf("Upload command failed: %w", err)
 	}
-	defer func() { _ = response.Body.Close() }()
-	body, err := io.ReadAll(response.Body)
+	err = writer.WriteResponse(*output.NewResponseInfo(http.StatusOK, "200 OK", "HTTP/1.1", map[string][]string{}, bytes.NewReader(json)))
 	if err != nil {
-		return fmt.Errorf("Error reading response: %w", err)
+		return err
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+		}
 	}
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+	if failed > 0 {
+		return fmt.Errorf("Failed to upload %d of %d files", failed, len(results))
 	}
 	return nil
 }
 
-func (c UploadCommand) createUploadRequest(ctx plugin.ExecutionContext, url string, uploadBar *visualization.ProgressBar, cancel context.CancelCauseFunc) *network.HttpRequest {
-	file := ctx.Input
-	if file == nil {
-		file = c.getFileParameter(ctx.Parameters)
+func (c UploadCommand) uploadFile(ctx plugin.ExecutionContext, logger log.Logger, client *api.OrchestratorClient, folderId int, bucketId int, upload fileUpload, progress *uploadProgress) error {
+	writeUrl, err := client.GetWriteUrl(folderId, bucketId, upload.Path)
+	if err != nil {
+		return err
 	}
-	bodyReader, bodyWriter := io.Pipe()
-	contentType, contentLength := c.writeBody(bodyWriter, file, cancel)
-	uploadReader := c.progressReader("uploading...", "completing  ", bodyReader, contentLength, uploadBar)
+	return c.upload(ctx, logger, writeUrl, upload.File, func(reader io.Reader, length int64) io.Reader {
+		return progress.Reader(reader)
+	})
+}
 
-	header := http.Header{
-		"Content-Type":   {contentType},
-		"x-ms-blob-type": {"BlockBlob"},
+func (c UploadCommand) totalSize(uploads []fileUpload) int64 {
+	total := int64(0)
+	for _, upload := range uploads {
+		size, err := upload.File.Size()
+		if err == nil && size > 0 {
+			total += size
+		}
 	}
-	return network.NewHttpPutRequest(url, nil, header, uploadReader, contentLength)
+	return total
 }
 
-func (c UploadCommand) writeBody(bodyWriter *io.PipeWriter, input stream.Stream, cancel context.CancelCauseFunc) (string, int64) {
-	go func() {
-		defer func() { _ = bodyWriter.Close() }()
-		data, err := input.Data()
+// expandFiles resolves glob patterns and derives the remote path for each file.
+// A single file is uploaded to the provided path, multiple files use the path as
+// prefix followed by the path relative to the glob pattern.
+func (c UploadCommand) expandFiles(files []stream.Stream, path string) ([]fileUpload, bool, error) {
+	multiple := len(files) > 1
+	uploads := []fileUpload{}
+	for _, file := range files {
+		fileStream, ok := file.(*stream.FileStream)
+		if !ok || !c.isGlobPattern(fileStream.Path()) {
+			uploads = append(uploads, *newFileUpload(file, file.Name()))
+			continue
+		}
+		multiple = true
+		matches, err := c.glob(fileStream.Path())
 		if err != nil {
-			cancel(err)
-			return
+			return nil, false, err
+		}
+		uploads = append(uploads, matches...)
+	}
+	if len(uploads) == 0 {
+		return nil, false, errors.New("Argument --file is missing")
+	}
+	if !multiple {
+		uploads[0].Path = path
+		return uploads, false, nil
+	}
+
+	prefix := strings.Trim(path, "/")
+	paths := map[string]bool{}
+	for i := range uploads {
+		if prefix != "" {
+			uploads[i].Path = prefix + "/" + uploads[i].Path
+		}
+		if paths[uploads[i].Path] {
+			return nil, false, fmt.Errorf("Multiple files are uploaded to the same path '%s'", uploads[i].Path)
+		}
+		paths[uploads[i].Path] = true
+	}
+	return uploads, true, nil
+}
+
+func (c UploadCommand) isGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+func (c UploadCommand) glob(pattern string) ([]fileUpload, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid glob pattern '%s'", pattern)
+	}
+	sort.Strings(matches)
+	baseDirectory := c.globBaseDirectory(pattern)
+	uploads := []fileUpload{}
+	for _, match := range matches {
+		file := stream.NewFileStream(match)
+		if size, err := file.Size(); err != nil || size < 0 || c.isDirectory(match) {
+			continue
 		}
-		defer func() { _ = data.Close() }()
-		_, err = io.Copy(bodyWriter, data)
+		relativePath, err := filepath.Rel(baseDirectory, match)
 		if err != nil {
-			cancel(err)
-			return
+			relativePath = filepath.Base(match)
 		}
-	}()
-	size, _ := input.Size()
-	return "application/octet-stream", size
+		uploads = append(uploads, *newFileUpload(file, filepath.ToSlash(relativePath)))
+	}
+	if len(uploads) == 0 {
+		return nil, fmt.Errorf("No files found matching '%s'", pattern)
+	}
+	return uploads, nil
+}
+
+func (c UploadCommand) globBaseDirectory(pattern string) string {
+	directory := filepath.Dir(pattern)
+	for c.isGlobPattern(directory) {
+		directory = filepath.Dir(directory)
+	}
+	return directory
+}
+
+func (c UploadCommand) isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func (c UploadCommand) upload(ctx plugin.ExecutionContext, logger log.Logger, url string, file stream.Stream, progress func(reader io.Reader, length int64) io.Reader) error {
+	client := network.NewHttpClient(logger, c.httpClientSettings(ctx))
+	uploader := orchestrator.NewBlobUploader(client)
+	return uploader.Upload(url, file, progress)
 }
 
 func (c UploadCommand) progressReader(text string, completedText string, reader io.Reader, length int64, progressBar *visualization.ProgressBar) io.Reader {
@@ -115,16 +259,9 @@ func (c UploadCommand) progressReader(text string, completedText string, reader
 	})
 }
 
-func (c UploadCommand) getWriteUrl(ctx plugin.ExecutionContext, logger log.Logger) (string, error) {
-	if ctx.Organization == "" {
-		return "", errors.New("Organization is not set")
-	}
-	if ctx.Tenant == "" {
-		return "", errors.New("Tenant is not set")
-	}
-	folderId := c.getIntParameter("folder-id", ctx.Parameters)
-	bucketId := c.getIntParameter("key", ctx.Parameters)
-	path := c.getStringParameter("path", ctx.Parameters)
+func (c UploadCommand) getWriteUrl(ctx plugin.ExecutionContext, logger log.Logger, path string) (string, error) {
+	folderId := c.getIntParameter("folder-id", 0, ctx.Parameters)
+	bucketId := c.getIntParameter("key", 0, ctx.Parameters)
 
 	client := api.NewOrchestratorClient(ctx.BaseUri, ctx.Organization, ctx.Tenant, ctx.Auth.Token, ctx.Debug, ctx.Settings, logger)
 	return client.GetWriteUrl(folderId, bucketId, path)
@@ -143,8 +280,8 @@ func (c UploadCommand) getStringParameter(name string, parameters []plugin.Execu
 	return result
 }
 
-func (c UploadCommand) getIntParameter(name string, parameters []plugin.ExecutionParameter) int {
-	result := 0
+func (c UploadCommand) getIntParameter(name string, defaultValue int, parameters []plugin.ExecutionParameter) int {
+	result := defaultValue
 	for _, p := range parameters {
 		if p.Name == name {
 			if data, ok := p.Value.(int); ok {
@@ -156,12 +293,15 @@ func (c UploadCommand) getIntParameter(name string, parameters []plugin.Executio
 	return result
 }
 
-func (c UploadCommand) getFileParameter(parameters []plugin.ExecutionParameter) stream.Stream {
-	var result stream.Stream
-	for _, p := range parameters {
+func (c UploadCommand) getFiles(ctx plugin.ExecutionContext) []stream.Stream {
+	if ctx.Input != nil {
+		return []stream.Stream{ctx.Input}
+	}
+	result := []stream.Stream{}
+	for _, p := range ctx.Parameters {
 		if p.Name == "file" {
-			if stream, ok := p.Value.(stream.Stream); ok {
-				result = stream
+			if streams, ok := p.Value.([]stream.Stream); ok {
+				result = streams
 				break
 			}
 		}
@@ -175,7 +315,11 @@ func (c UploadCommand) httpClientSettings(ctx plugin.ExecutionContext) network.H
 		ctx.Settings.OperationId,
 		ctx.Settings.Header,
 		ctx.Settings.Timeout,
-		ctx.Settings.MaxAttempts,
+		ctx.Settings.Retry,
+		ctx.Settings.RetryNonIdempotent,
+		ctx.Settings.RateLimit,
+		ctx.Settings.Connection,
+		ctx.Settings.Tracer,
 		ctx.Settings.Insecure)
 }
 
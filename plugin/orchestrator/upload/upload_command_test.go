@@ -2,11 +2,16 @@ package upload
 
 import (
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/UiPath/uipathcli/commandline"
 	"github.com/UiPath/uipathcli/test"
 )
 
@@ -145,6 +150,34 @@ func TestUploadWithFailedResponseReturnsError(t *testing.T) {
 	}
 }
 
+func TestUploadWithFailedResponseReturnsHttpError(t *testing.T) {
+	path := test.CreateTempFile(t, "hello-world")
+
+	config := `profiles:
+- name: default
+  organization: my-org
+  tenant: my-tenant
+`
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", "").
+		WithConfig(config).
+		WithCommandPlugin(NewUploadCommand()).
+		WithResponse(http.StatusNotFound, `{"message":"Bucket does not exist.","errorCode":1002}`).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "upload", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--file", path, "--error-format", "json"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != commandline.ExitCodeNotFound {
+		t.Errorf("Expected not found exit code, but got: %v", exitCode)
+	}
+	expected := `{"category":"notFound","exitCode":4,"message":"Orchestrator returned status code '404': Bucket does not exist. (error code: 1002)","status":404,`
+	if !strings.HasPrefix(result.StdErr, expected) || !strings.Contains(result.StdErr, `"service":{"errorCode":1002,"message":"Bucket does not exist."}`) {
+		t.Errorf("Expected structured http error on stderr, but got: %v", result.StdErr)
+	}
+}
+
 func TestUploadSuccessfully(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
@@ -283,3 +316,201 @@ servers:
 		t.Errorf("Expected stderr to contain upload request, but got: %v", result.StdErr)
 	}
 }
+
+const multiUploadDefinition = `
+servers:
+- url: https://cloud.uipath.com/{organization}/{tenant}/orchestrator_
+  description: The production url
+  variables:
+    organization:
+      description: The organization name (or id)
+      default: my-org
+    tenant:
+      description: The tenant name (or id)
+      default: my-tenant
+`
+
+type uploadServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	uploaded map[string]string
+}
+
+func (s *uploadServer) Uploaded() map[string]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return maps.Clone(s.uploaded)
+}
+
+func newUploadServer(failingPath string) *uploadServer {
+	server := &uploadServer{uploaded: map[string]string{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if path == failingPath {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		server.mutex.Lock()
+		server.uploaded[path] = string(body)
+		server.mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return server
+}
+
+func writeUrlHandler(storageUrl string) func(test.RequestData) test.ResponseData {
+	return func(request test.RequestData) test.ResponseData {
+		path := request.URL.Query().Get("path")
+		return test.ResponseData{Status: http.StatusOK, Body: `{"Uri":"` + storageUrl + `/` + path + `"}`}
+	}
+}
+
+func createFile(t *testing.T, path string, content string) {
+	_ = os.MkdirAll(filepath.Dir(path), 0700)
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUploadGlobPatternUploadsAllMatchingFilesWithPrefix(t *testing.T) {
+	storage := newUploadServer("")
+	defer storage.Close()
+
+	directory := t.TempDir()
+	createFile(t, filepath.Join(directory, "invoices", "a.pdf"), "a")
+	createFile(t, filepath.Join(directory, "invoices", "b.pdf"), "b")
+	createFile(t, filepath.Join(directory, "invoices", "c.txt"), "c")
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", multiUploadDefinition).
+		WithCommandPlugin(NewUploadCommand()).
+		WithResponseHandler(writeUrlHandler(storage.URL)).
+		Build()
+
+	pattern := filepath.Join(directory, "invoices", "*.pdf")
+	result := test.RunCli([]string{"orchestrator", "buckets", "upload", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "archive/2024", "--file", pattern}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	uploaded := storage.Uploaded()
+	if len(uploaded) != 2 || uploaded["archive/2024/a.pdf"] != "a" || uploaded["archive/2024/b.pdf"] != "b" {
+		t.Errorf("Expected matching files to be uploaded, but got: %v", uploaded)
+	}
+	expected := `[
+  {
+    "error": null,
+    "file": "a.pdf",
+    "path": "archive/2024/a.pdf",
+    "size": 1,
+    "status": "Succeeded"
+  },
+  {
+    "error": null,
+    "file": "b.pdf",
+    "path": "archive/2024/b.pdf",
+    "size": 1,
+    "status": "Succeeded"
+  }
+]
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected upload results on stdout, but got: %v", result.StdOut)
+	}
+}
+
+func TestUploadMultipleFilesUsesFileNames(t *testing.T) {
+	storage := newUploadServer("")
+	defer storage.Close()
+
+	directory := t.TempDir()
+	createFile(t, filepath.Join(directory, "first.txt"), "first")
+	createFile(t, filepath.Join(directory, "second.txt"), "second")
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", multiUploadDefinition).
+		WithCommandPlugin(NewUploadCommand()).
+		WithResponseHandler(writeUrlHandler(storage.URL)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "upload", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "/docs/", "--file", filepath.Join(directory, "first.txt"), "--file", filepath.Join(directory, "second.txt"), "--parallelism", "1"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	uploaded := storage.Uploaded()
+	if len(uploaded) != 2 || uploaded["docs/first.txt"] != "first" || uploaded["docs/second.txt"] != "second" {
+		t.Errorf("Expected files to be uploaded, but got: %v", uploaded)
+	}
+}
+
+func TestUploadMultipleFilesReportsFailedUploads(t *testing.T) {
+	storage := newUploadServer("docs/second.txt")
+	defer storage.Close()
+
+	directory := t.TempDir()
+	createFile(t, filepath.Join(directory, "first.txt"), "first")
+	createFile(t, filepath.Join(directory, "second.txt"), "second")
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", multiUploadDefinition).
+		WithCommandPlugin(NewUploadCommand()).
+		WithResponseHandler(writeUrlHandler(storage.URL)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "upload", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "docs", "--file", filepath.Join(directory, "*.txt")}, context)
+
+	if !strings.Contains(result.StdErr, "Failed to upload 1 of 2 files") {
+		t.Errorf("Expected stderr to show failed uploads, but got: %v", result.StdErr)
+	}
+	if !strings.Contains(result.StdOut, `"status": "Failed"`) || !strings.Contains(result.StdOut, `"error": "Orchestrator returned status code '403'`) {
+		t.Errorf("Expected stdout to contain failed upload result, but got: %v", result.StdOut)
+	}
+	if storage.Uploaded()["docs/first.txt"] != "first" {
+		t.Errorf("Expected first file to be uploaded, but got: %v", storage.Uploaded())
+	}
+}
+
+func TestUploadGlobPatternWithoutMatchesShowsError(t *testing.T) {
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", multiUploadDefinition).
+		WithCommandPlugin(NewUploadCommand()).
+		Build()
+
+	pattern := filepath.Join(t.TempDir(), "*.pdf")
+	result := test.RunCli([]string{"orchestrator", "buckets", "upload", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "archive", "--file", pattern}, context)
+
+	if !strings.Contains(result.StdErr, "No files found matching '"+pattern+"'") {
+		t.Errorf("Expected stderr to show that no files matched, but got: %v", result.StdErr)
+	}
+}
+
+func TestUploadDryRunShowsValidationErrorAndDoesNotUpload(t *testing.T) {
+	storage := newUploadServer("")
+	defer storage.Close()
+
+	path := test.CreateTempFile(t, "hello-world")
+
+	context := test.NewContextBuilder().
+		WithDefinition("orchestrator", multiUploadDefinition).
+		WithCommandPlugin(NewUploadCommand()).
+		WithResponseHandler(writeUrlHandler(storage.URL)).
+		Build()
+
+	result := test.RunCli([]string{"orchestrator", "buckets", "upload", "--organization", "myorg", "--tenant", "mytenant", "--folder-id", "1", "--key", "2", "--path", "file.txt", "--file", path, "--dry-run"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Dry run is not supported for this command" {
+		t.Errorf("Expected dry run not supported error, but got: %v", result.Error)
+	}
+	if commandline.ExitCode(result.Error) != commandline.ExitCodeValidationError {
+		t.Errorf("Expected validation error exit code, but got: %v", commandline.ExitCode(result.Error))
+	}
+	if len(storage.Uploaded()) != 0 {
+		t.Errorf("Expected no PUT request during dry run, but got: %v", storage.Uploaded())
+	}
+	if result.RequestUrl != "" {
+		t.Errorf("Expected no request during dry run, but got: %v", result.RequestUrl)
+	}
+}
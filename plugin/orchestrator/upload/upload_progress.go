@@ -0,0 +1,42 @@
+package upload
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/UiPath/uipathcli/utils/visualization"
+)
+
+// The uploadProgress aggregates the progress of multiple concurrent uploads
+// and displays it in a single progress bar.
+type uploadProgress struct {
+	mutex       sync.Mutex
+	progressBar *visualization.ProgressBar
+	total       int64
+	uploaded    int64
+	startTime   time.Time
+}
+
+func (p *uploadProgress) Reader(reader io.Reader) io.Reader {
+	if p.total < 10*1024*1024 {
+		return reader
+	}
+	bytesRead := int64(0)
+	return visualization.NewProgressReader(reader, func(progress visualization.Progress) {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		p.uploaded += progress.BytesRead - bytesRead
+		bytesRead = progress.BytesRead
+		bytesPerSecond := int64(0)
+		seconds := time.Since(p.startTime).Seconds()
+		if seconds > 0 {
+			bytesPerSecond = int64(float64(p.uploaded) / seconds)
+		}
+		p.progressBar.UpdateProgress("uploading...", p.uploaded, p.total, bytesPerSecond)
+	})
+}
+
+func newUploadProgress(progressBar *visualization.ProgressBar, total int64) *uploadProgress {
+	return &uploadProgress{progressBar: progressBar, total: total, startTime: time.Now()}
+}
@@ -0,0 +1,19 @@
+package upload
+
+type uploadResult struct {
+	Status string  `json:"status"`
+	File   string  `json:"file"`
+	Path   string  `json:"path"`
+	Size   int64   `json:"size"`
+	Error  *string `json:"error"`
+}
+
+func newSucceededUploadResult(upload fileUpload) *uploadResult {
+	size, _ := upload.File.Size()
+	return &uploadResult{"Succeeded", upload.File.Name(), upload.Path, size, nil}
+}
+
+func newFailedUploadResult(upload fileUpload, err string) *uploadResult {
+	size, _ := upload.File.Size()
+	return &uploadResult{"Failed", upload.File.Name(), upload.Path, size, &err}
+}
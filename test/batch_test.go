@@ -0,0 +1,395 @@
+package test
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBatchExecutesOperationForEveryJsonLine(t *testing.T) {
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required:
+              - name
+              properties:
+                name:
+                  type: string
+                count:
+                  type: integer
+`
+	path := CreateTempFile(t, `{"name": "asset1", "count": 1}
+{"name": "asset2", "count": 2}
+`)
+
+	bodies := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			bodies = append(bodies, string(request.Body))
+			return ResponseData{Status: http.StatusCreated, Body: `{"id":1}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-asset", "--batch", path}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	expectedBodies := []string{`{"count":1,"name":"asset1"}`, `{"count":2,"name":"asset2"}`}
+	if strings.Join(bodies, "\n") != strings.Join(expectedBodies, "\n") {
+		t.Errorf("Expected request bodies %v, got: %v", expectedBodies, bodies)
+	}
+	stdout := ParseOutput(t, result.StdOut)
+	if stdout["total"] != 2.0 || stdout["succeeded"] != 2.0 || stdout["failed"] != 0.0 {
+		t.Errorf("Expected batch summary in output, got: %v", result.StdOut)
+	}
+	results := stdout["results"].([]interface{})
+	first := results[0].(map[string]interface{})
+	if first["row"] != 1.0 || first["status"] != "succeeded" || first["statusCode"] != 201.0 {
+		t.Errorf("Expected row result in output, got: %v", first)
+	}
+	body := first["body"].(map[string]interface{})
+	if body["id"] != 1.0 {
+		t.Errorf("Expected response body in row result, got: %v", first)
+	}
+}
+
+func TestBatchExecutesOperationForEveryCsvRow(t *testing.T) {
+	definition := `
+paths:
+  /machines/{id}:
+    delete:
+      operationId: deleteMachine
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	path := filepath.Join(t.TempDir(), "machines.csv")
+	err := os.WriteFile(path, []byte("id\n1\n2\n3\n"), 0600)
+	if err != nil {
+		t.Fatalf("Error writing file '%s': %v", path, err)
+	}
+
+	urls := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			urls = append(urls, request.URL.Path)
+			return ResponseData{Status: http.StatusNoContent, Body: ""}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "delete-machine", "--batch", path, "--query", "succeeded"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if strings.Join(urls, ",") != "/machines/1,/machines/2,/machines/3" {
+		t.Errorf("Expected request for every row, got: %v", urls)
+	}
+	if result.StdOut != "3\n" {
+		t.Errorf("Expected 3 succeeded rows, got: %v", result.StdOut)
+	}
+}
+
+func TestBatchUsesCommandLineArgumentsForMissingValues(t *testing.T) {
+	definition := `
+paths:
+  /queues:
+    post:
+      operationId: addQueueItem
+      parameters:
+      - name: X-UIPATH-OrganizationUnitId
+        in: header
+        required: true
+        x-uipathcli-name: folder-id
+        schema:
+          type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                reference:
+                  type: string
+`
+	path := CreateTempFile(t, `{"reference": "ref1"}`)
+
+	folderIds := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			folderIds = append(folderIds, request.Header["x-uipath-organizationunitid"])
+			return ResponseData{Status: http.StatusOK, Body: ""}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "add-queue-item", "--batch", path, "--folder-id", "123"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if strings.Join(folderIds, ",") != "123" {
+		t.Errorf("Expected folder id from command line, got: %v", folderIds)
+	}
+}
+
+func TestBatchReportsValidationErrorsPerRow(t *testing.T) {
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required:
+              - name
+              properties:
+                name:
+                  type: string
+                count:
+                  type: integer
+`
+	path := CreateTempFile(t, `{"name": "asset1"}
+{"count": 2}
+{"name": "asset3", "count": "invalid"}
+{"name": "asset4", "unknown": "value"}
+`)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusCreated, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-asset", "--batch", path}, context)
+
+	if result.Error == nil || result.Error.Error() != "Batch execution failed for 3 of 4 rows" {
+		t.Errorf("Expected batch execution error, got: %v", result.Error)
+	}
+	stdout := ParseOutput(t, result.StdOut)
+	results := stdout["results"].([]interface{})
+	errors := []string{}
+	for _, result := range results {
+		message, _ := result.(map[string]interface{})["error"].(string)
+		errors = append(errors, message)
+	}
+	expected := []string{
+		"",
+		"Invalid arguments:\n  Argument --name is missing",
+		"Cannot convert 'count' value 'invalid' to integer",
+		"Unknown argument 'unknown'",
+	}
+	if strings.Join(errors, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected row errors %v, got: %v", expected, errors)
+	}
+}
+
+func TestBatchMarksHttpErrorsAsFailed(t *testing.T) {
+	definition := `
+paths:
+  /assets/{id}:
+    delete:
+      operationId: deleteAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	path := CreateTempFile(t, `{"id": 1}
+{"id": 2}
+`)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			if request.URL.Path == "/assets/2" {
+				return ResponseData{Status: http.StatusNotFound, Body: `{"message":"Not found"}`}
+			}
+			return ResponseData{Status: http.StatusNoContent, Body: ""}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "delete-asset", "--batch", path, "--query", "results[].[status, statusCode]", "--output", "text"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Batch execution failed for 1 of 2 rows" {
+		t.Errorf("Expected batch execution error, got: %v", result.Error)
+	}
+	expected := "succeeded\t204\nfailed\t404\n"
+	if result.StdOut != expected {
+		t.Errorf("Expected row status on stdout %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestBatchExecutesRowsConcurrently(t *testing.T) {
+	definition := `
+paths:
+  /assets/{id}:
+    get:
+      operationId: getAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	path := CreateTempFile(t, "{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n{\"id\": 4}\n")
+
+	var mutex sync.Mutex
+	paths := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			mutex.Lock()
+			defer mutex.Unlock()
+			paths = append(paths, request.URL.Path)
+			return ResponseData{Status: http.StatusOK, Body: `{}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-asset", "--batch", path, "--batch-concurrency", "4", "--query", "results[].row"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	sort.Strings(paths)
+	if strings.Join(paths, ",") != "/assets/1,/assets/2,/assets/3,/assets/4" {
+		t.Errorf("Expected request for every row, got: %v", paths)
+	}
+	expected := "[\n  1,\n  2,\n  3,\n  4\n]\n"
+	if result.StdOut != expected {
+		t.Errorf("Expected results in row order %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestBatchReadsInputFromStdIn(t *testing.T) {
+	definition := `
+paths:
+  /assets/{id}:
+    get:
+      operationId: getAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithStdIn(*bytes.NewBufferString(`{"id": 5}`)).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-asset", "--batch", "-", "--query", "results[0].statusCode"}, context)
+
+	if result.StdOut != "200\n" {
+		t.Errorf("Expected row result on stdout, got: %v", result.StdOut)
+	}
+}
+
+func TestBatchInvalidJsonLineReturnsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	path := CreateTempFile(t, "{}\n{invalid}\n")
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--batch", path}, context)
+
+	if result.Error == nil || !strings.HasPrefix(result.Error.Error(), "Invalid batch input in row 2:") {
+		t.Errorf("Expected invalid batch input error, got: %v", result.Error)
+	}
+}
+
+func TestBatchInvalidConcurrencyReturnsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	path := CreateTempFile(t, "{}\n")
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--batch", path, "--batch-concurrency", "0"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Invalid value for 'batch-concurrency'" {
+		t.Errorf("Expected invalid concurrency error, got: %v", result.Error)
+	}
+}
+
+func TestBatchWithNdJsonOutputWritesEveryRow(t *testing.T) {
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+`
+	path := CreateTempFile(t, `{"name": "asset1"}
+{"name": "asset2"}
+`)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			if strings.Contains(string(request.Body), "asset2") {
+				return ResponseData{Status: http.StatusConflict, Body: `{"message":"exists"}`}
+			}
+			return ResponseData{Status: http.StatusCreated, Body: `{"id":1}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-asset", "--batch", path, "--output", "ndjson"}, context)
+
+	expected := `{"body":{"id":1},"row":1,"status":"succeeded","statusCode":201}
+{"body":{"message":"exists"},"row":2,"status":"failed","statusCode":409}
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected one line per row on stdout %v, got: %v", expected, result.StdOut)
+	}
+	if !strings.Contains(result.StdErr, "Batch execution failed for 1 of 2 rows") {
+		t.Errorf("Expected batch failure on stderr, got: %v", result.StdErr)
+	}
+}
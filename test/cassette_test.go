@@ -0,0 +1,226 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/UiPath/uipathcli/utils/network"
+)
+
+func TestRecordStoresResponseInCassette(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	directory := t.TempDir()
+	t.Setenv(network.RecordDirectoryVarName, directory)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"hello":"world"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	files, _ := filepath.Glob(filepath.Join(directory, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("Expected a single cassette file, but got: %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	cassette := string(data)
+	if !strings.Contains(cassette, `"method": "GET"`) || !strings.Contains(cassette, `"url": "`+result.BaseUrl+`/ping"`) {
+		t.Errorf("Expected request in cassette, but got: %v", cassette)
+	}
+	if !strings.Contains(cassette, `"statusCode": 200`) || !strings.Contains(cassette, `"body": "{\"hello\":\"world\"}"`) {
+		t.Errorf("Expected response in cassette, but got: %v", cassette)
+	}
+}
+
+func TestRecordRedactsTokensAndSensitiveHeaders(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  auth:
+    clientId: my-client-id
+    clientSecret: my-client-secret
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	directory := t.TempDir()
+	t.Setenv(network.RecordDirectoryVarName, directory)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithIdentityResponse(http.StatusOK, `{"access_token":"my-secret-access-token","refresh_token":"my-secret-refresh-token","expires_in":3600,"token_type":"Bearer"}`).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			return ResponseData{Status: http.StatusOK, Body: `{"hello":"world"}`, Header: map[string]string{"Set-Cookie": "session=my-secret-cookie"}}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	files, _ := filepath.Glob(filepath.Join(directory, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("Expected cassette files for token and ping request, but got: %v", files)
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		cassette := string(data)
+		for _, secret := range []string{"my-secret-access-token", "my-secret-refresh-token", "my-secret-cookie"} {
+			if strings.Contains(cassette, secret) {
+				t.Errorf("Expected %s to be redacted in cassette, but got: %v", secret, cassette)
+			}
+		}
+		if !strings.Contains(cassette, "**redacted**") {
+			t.Errorf("Expected redacted value in cassette, but got: %v", cassette)
+		}
+	}
+}
+
+func TestReplayReturnsRecordedResponse(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	directory := t.TempDir()
+	t.Setenv(network.RecordDirectoryVarName, directory)
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"hello":"world"}`).
+		Build()
+	recorded := RunCli([]string{"myservice", "ping"}, context)
+
+	t.Setenv(network.RecordDirectoryVarName, "")
+	t.Setenv(network.ReplayDirectoryVarName, directory)
+	context = NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+	result := RunCli([]string{"myservice", "ping", "--uri", recorded.BaseUrl}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if result.StdOut != recorded.StdOut {
+		t.Errorf("Expected recorded response %v, but got: %v", recorded.StdOut, result.StdOut)
+	}
+}
+
+func TestReplayMatchesNormalizedJsonBody(t *testing.T) {
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+`
+	directory := t.TempDir()
+	t.Setenv(network.RecordDirectoryVarName, directory)
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusCreated, `{"id":1}`).
+		Build()
+	recordPath := CreateTempFile(t, `{"name": "asset", "value": 1}`)
+	recorded := RunCli([]string{"myservice", "create-asset", "--file", recordPath}, context)
+
+	t.Setenv(network.RecordDirectoryVarName, "")
+	t.Setenv(network.ReplayDirectoryVarName, directory)
+	context = NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+	replayPath := CreateTempFile(t, `{"value":1,"name":"asset"}`)
+	result := RunCli([]string{"myservice", "create-asset", "--file", replayPath, "--uri", recorded.BaseUrl}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if result.StdOut != recorded.StdOut {
+		t.Errorf("Expected recorded response %v, but got: %v", recorded.StdOut, result.StdOut)
+	}
+}
+
+func TestReplayReturnsResponsesInRecordedOrder(t *testing.T) {
+	definition := `
+paths:
+  /jobs/{id}:
+    get:
+      operationId: getJob
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	directory := t.TempDir()
+	t.Setenv(network.RecordDirectoryVarName, directory)
+	requestCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			requestCount++
+			return ResponseData{Status: http.StatusOK, Body: fmt.Sprintf(`{"state":"state%d"}`, requestCount)}
+		}).
+		Build()
+	path := CreateTempFile(t, "{\"id\": 1}\n{\"id\": 1}\n")
+	recorded := RunCli([]string{"myservice", "get-job", "--batch", path}, context)
+
+	t.Setenv(network.RecordDirectoryVarName, "")
+	t.Setenv(network.ReplayDirectoryVarName, directory)
+	context = NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+	states := []string{}
+	for i := 0; i < 3; i++ {
+		result := RunCli([]string{"myservice", "get-job", "--id", "1", "--uri", recorded.BaseUrl, "--query", "state", "--output", "text"}, context)
+		states = append(states, strings.TrimSpace(result.StdOut))
+	}
+
+	if strings.Join(states, ",") != "state1,state2,state2" {
+		t.Errorf("Expected responses in recorded order, but got: %v", states)
+	}
+}
+
+func TestReplayUnmatchedRequestReturnsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	directory := t.TempDir()
+	t.Setenv(network.ReplayDirectoryVarName, directory)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--uri", "https://cloud.uipath.com"}, context)
+
+	expected := "No recorded response found in '" + directory + "' for request GET https://cloud.uipath.com/ping"
+	if result.Error == nil || !strings.HasPrefix(result.Error.Error(), expected) {
+		t.Errorf("Expected unmatched request error %v, but got: %v", expected, result.Error)
+	}
+}
@@ -166,6 +166,122 @@ func TestConfigSetInsecure(t *testing.T) {
 	}
 }
 
+func TestConfigSetProxy(t *testing.T) {
+	configFile := TempFile(t)
+	context := NewContextBuilder().
+		WithConfigFile(configFile).
+		Build()
+
+	RunCli([]string{"config", "set", "--key", "proxy", "--value", "http://proxy.corp.local:8080"}, context)
+
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Errorf("Config file does not exist: %v", err)
+	}
+	expectedConfig := `profiles:
+- name: default
+  proxy: http://proxy.corp.local:8080
+`
+	if string(config) != expectedConfig {
+		t.Errorf("Expected generated config %v, but got %v", expectedConfig, string(config))
+	}
+}
+
+func TestConfigSetNoProxy(t *testing.T) {
+	configFile := TempFile(t)
+	context := NewContextBuilder().
+		WithConfigFile(configFile).
+		Build()
+
+	RunCli([]string{"config", "set", "--key", "noProxy", "--value", "localhost,.corp.local"}, context)
+
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Errorf("Config file does not exist: %v", err)
+	}
+	expectedConfig := `profiles:
+- name: default
+  noProxy: localhost,.corp.local
+`
+	if string(config) != expectedConfig {
+		t.Errorf("Expected generated config %v, but got %v", expectedConfig, string(config))
+	}
+}
+
+func TestConfigSetCaCertFile(t *testing.T) {
+	configFile := TempFile(t)
+	context := NewContextBuilder().
+		WithConfigFile(configFile).
+		Build()
+
+	RunCli([]string{"config", "set", "--key", "caCertFile", "--value", "/etc/ssl/corp-ca.pem"}, context)
+
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Errorf("Config file does not exist: %v", err)
+	}
+	expectedConfig := `profiles:
+- name: default
+  caCertFile: /etc/ssl/corp-ca.pem
+`
+	if string(config) != expectedConfig {
+		t.Errorf("Expected generated config %v, but got %v", expectedConfig, string(config))
+	}
+}
+
+func TestConfigSetClientCertFile(t *testing.T) {
+	configFile := TempFile(t)
+	context := NewContextBuilder().
+		WithConfigFile(configFile).
+		Build()
+
+	RunCli([]string{"config", "set", "--key", "clientCertFile", "--value", "/etc/ssl/client.pem"}, context)
+
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Errorf("Config file does not exist: %v", err)
+	}
+	expectedConfig := `profiles:
+- name: default
+  clientCertFile: /etc/ssl/client.pem
+`
+	if string(config) != expectedConfig {
+		t.Errorf("Expected generated config %v, but got %v", expectedConfig, string(config))
+	}
+}
+
+func TestConfigSetClientKeyFile(t *testing.T) {
+	configFile := TempFile(t)
+	context := NewContextBuilder().
+		WithConfigFile(configFile).
+		Build()
+
+	RunCli([]string{"config", "set", "--key", "clientKeyFile", "--value", "/etc/ssl/client-key.pem"}, context)
+
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Errorf("Config file does not exist: %v", err)
+	}
+	expectedConfig := `profiles:
+- name: default
+  clientKeyFile: /etc/ssl/client-key.pem
+`
+	if string(config) != expectedConfig {
+		t.Errorf("Expected generated config %v, but got %v", expectedConfig, string(config))
+	}
+}
+
+func TestConfigInvalidProxy(t *testing.T) {
+	context := NewContextBuilder().
+		Build()
+
+	result := RunCli([]string{"config", "set", "--key", "proxy", "--value", "invalid uri\t"}, context)
+
+	if !strings.HasPrefix(result.StdErr, "Invalid value for 'proxy'") {
+		t.Errorf("Expected invalid proxy error, but got %v", result.StdErr)
+	}
+}
+
 func TestConfigSetDebug(t *testing.T) {
 	configFile := TempFile(t)
 	context := NewContextBuilder().
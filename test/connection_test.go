@@ -0,0 +1,159 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxyFromProfileIsUsed(t *testing.T) {
+	proxiedHost := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_, _ = w.Write([]byte(`{"proxied":true}`))
+	}))
+	defer proxy.Close()
+
+	config := `
+profiles:
+- name: default
+  proxy: ` + proxy.URL + `
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--uri", "http://myservice.invalid"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if proxiedHost != "myservice.invalid" {
+		t.Errorf("Expected request to be sent through proxy, got: %v", proxiedHost)
+	}
+	if result.StdOut != "{\n  \"proxied\": true\n}\n" {
+		t.Errorf("Expected proxy response on stdout, got: %v", result.StdOut)
+	}
+}
+
+func TestNoProxyFromProfileBypassesProxy(t *testing.T) {
+	proxied := false
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = true
+	}))
+	defer proxy.Close()
+
+	config := `
+profiles:
+- name: default
+  proxy: ` + proxy.URL + `
+  noProxy: 127.0.0.1
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if proxied {
+		t.Errorf("Expected request to bypass the proxy")
+	}
+}
+
+func TestInvalidCaCertFileFromProfileShowsError(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  caCertFile: does-not-exist.pem
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	expected := "Error reading CA certificate file: open does-not-exist.pem: no such file or directory"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected error %v, got: %v", expected, result.Error)
+	}
+}
+
+func TestConnectionPoolFromProfileIsUsed(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  connectionPool:
+    maxIdle: 20
+    maxIdlePerHost: 5
+    idleTimeout: 30s
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+}
+
+func TestInvalidConnectionPoolFromProfileShowsError(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  connectionPool:
+    idleTimeout: invalid
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Invalid value for 'connectionPool.idleTimeout'" {
+		t.Errorf("Expected invalid value error, got: %v", result.Error)
+	}
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDryRunDoesNotSendRequest(t *testing.T) {
+	definition := `
+paths:
+  /assets/{id}:
+    post:
+      operationId: updateAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+      - name: filter
+        in: query
+        schema:
+          type: string
+      - name: x-uipath-folder
+        in: header
+        schema:
+          type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+`
+
+	requestCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			requestCount++
+			return ResponseData{Status: http.StatusOK, Body: ""}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--filter", "my-filter", "--x-uipath-folder", "Shared", "--name", "my-asset", "--dry-run"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if requestCount != 0 {
+		t.Errorf("Expected no request to be sent, but got: %d", requestCount)
+	}
+	stdout := ParseOutput(t, result.StdOut)
+	if stdout["method"] != "POST" {
+		t.Errorf("Expected method in output, got: %v", result.StdOut)
+	}
+	expectedUrl := result.BaseUrl + "/assets/1?filter=my-filter"
+	if stdout["url"] != expectedUrl {
+		t.Errorf("Expected url %v in output, got: %v", expectedUrl, stdout["url"])
+	}
+	header := stdout["header"].(map[string]interface{})
+	if header["Content-Type"] != "application/json" || header["X-Uipath-Folder"] != "Shared" || header["User-Agent"] == nil {
+		t.Errorf("Expected request headers in output, got: %v", header)
+	}
+	if header["Authorization"] != nil {
+		t.Errorf("Expected no authorization header without dry-run-auth, got: %v", header)
+	}
+	body := stdout["body"].(map[string]interface{})
+	if body["name"] != "my-asset" {
+		t.Errorf("Expected request body in output, got: %v", body)
+	}
+}
+
+func TestDryRunAuthRedactsAuthorizationHeader(t *testing.T) {
+	config := `
+profiles:
+  - name: default
+    auth:
+      pat: rt_mypat
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--dry-run", "--dry-run-auth", "--query", "header.Authorization"}, context)
+
+	if result.StdOut != "\"Bearer ***\"\n" {
+		t.Errorf("Expected redacted authorization header, got: %v", result.StdOut)
+	}
+}
+
+func TestDryRunRedactsAuthorizationHeaderFromProfile(t *testing.T) {
+	config := `
+profiles:
+  - name: default
+    header:
+      Authorization: my-secret-api-key
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--dry-run", "--query", "header.Authorization"}, context)
+
+	if result.StdOut != "\"***\"\n" {
+		t.Errorf("Expected redacted authorization header, got: %v", result.StdOut)
+	}
+}
+
+func TestDryRunShowsMultipartBody(t *testing.T) {
+	definition := `
+paths:
+  /upload:
+    post:
+      operationId: upload
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file:
+                  type: string
+                  format: binary
+`
+
+	path := CreateTempFile(t, "hello-world")
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "upload", "--file", path, "--dry-run", "--query", "body"}, context)
+
+	if !strings.Contains(result.StdOut, "hello-world") {
+		t.Errorf("Expected file content in dry run body, got: %v", result.StdOut)
+	}
+}
+
+func TestDryRunInvalidFileReturnsError(t *testing.T) {
+	definition := `
+paths:
+  /upload:
+    post:
+      operationId: upload
+      requestBody:
+        content:
+          application/octet-stream:
+            schema:
+              type: string
+              format: binary
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "upload", "--file", "not-found.txt", "--dry-run"}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected error for missing file, got: %v", result.StdOut)
+	}
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestErrorFormatJsonServiceError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusForbidden, `{"message":"You are not authorized!","errorCode":0,"traceId":"00-d1d2f4c4-00"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--error-format", "json"}, context)
+
+	stderr := ParseErrorOutput(t, result.StdErr)
+	expected := map[string]interface{}{
+		"category":  "authenticationError",
+		"exitCode":  3.0,
+		"message":   "Service returned status code '403': You are not authorized! (error code: 0, trace id: 00-d1d2f4c4-00)",
+		"status":    403.0,
+		"requestId": result.RequestHeader["x-request-id"],
+		"service": map[string]interface{}{
+			"errorCode": 0.0,
+			"message":   "You are not authorized!",
+			"traceId":   "00-d1d2f4c4-00",
+		},
+	}
+	AssertErrorOutput(t, expected, stderr)
+}
+
+func TestErrorFormatJsonServiceErrorWithoutBody(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusInternalServerError, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--error-format", "json", "--max-attempts", "1"}, context)
+
+	stderr := ParseErrorOutput(t, result.StdErr)
+	expected := map[string]interface{}{
+		"category":  "serverError",
+		"exitCode":  7.0,
+		"message":   "Service returned status code '500' and body ''",
+		"status":    500.0,
+		"requestId": result.RequestHeader["x-request-id"],
+	}
+	AssertErrorOutput(t, expected, stderr)
+}
+
+func TestErrorFormatJsonDoesNotWriteErrorBodyToStandardOutput(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--error-format", "json"}, context)
+
+	if result.StdOut != "" {
+		t.Errorf("Expected no error body on standard output, but got: %v", result.StdOut)
+	}
+	stderr := ParseErrorOutput(t, result.StdErr)
+	if stderr["message"] != "Service returned status code '404': Not found" {
+		t.Errorf("Expected error message on standard error, but got: %v", stderr["message"])
+	}
+}
+
+func TestErrorFormatJsonNoFailOnHttpErrorWritesBodyToStandardOutput(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--error-format", "json", "--no-fail-on-http-error"}, context)
+
+	if result.StdOut != "{\n  \"message\": \"Not found\"\n}\n" {
+		t.Errorf("Expected response body on standard output, but got: %v", result.StdOut)
+	}
+}
+
+func TestErrorFormatJsonValidationError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "invalid", "--error-format=json"}, context)
+
+	stderr := ParseErrorOutput(t, result.StdErr)
+	expected := map[string]interface{}{
+		"category": "validationError",
+		"exitCode": 2.0,
+		"message":  "Invalid output format 'invalid', allowed values: json, text, table, csv, tsv, yaml, template, ndjson",
+	}
+	AssertErrorOutput(t, expected, stderr)
+}
+
+func TestErrorFormatJsonCommandNotFound(t *testing.T) {
+	context := NewContextBuilder().
+		Build()
+
+	result := RunCli([]string{"unknown-service", "--error-format", "json"}, context)
+
+	stderr := ParseErrorOutput(t, result.StdErr)
+	expected := map[string]interface{}{
+		"category": "error",
+		"exitCode": 1.0,
+		"message":  "Command 'unknown-service' not found",
+	}
+	AssertErrorOutput(t, expected, stderr)
+}
+
+func TestErrorFormatJsonPluginError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: my-failed-command
+`
+	context := NewContextBuilder().
+		WithDefinition("mypluginservice", definition).
+		WithCommandPlugin(ErrorPluginCommand{}).
+		Build()
+
+	result := RunCli([]string{"mypluginservice", "my-failed-command", "--error-format", "json"}, context)
+
+	stderr := ParseErrorOutput(t, result.StdErr)
+	if stderr["message"] != "Internal server error when calling mypluginservice" {
+		t.Errorf("Expected plugin error message, but got: %v", stderr["message"])
+	}
+	if stderr["category"] != "error" {
+		t.Errorf("Expected error category, but got: %v", stderr["category"])
+	}
+	requestId, _ := stderr["requestId"].(string)
+	if len(requestId) != 32 {
+		t.Errorf("Expected request id, but got: %v", stderr["requestId"])
+	}
+}
+
+func TestErrorFormatTextIsDefault(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	expected := "Service returned status code '404': Not found\n"
+	if result.StdErr != expected {
+		t.Errorf("Expected plain text error, but got: %v", result.StdErr)
+	}
+}
+
+func TestErrorFormatInvalidValueShowsValidationError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--error-format", "xml"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Invalid error format 'xml', allowed values: text, json" {
+		t.Errorf("Expected invalid error format validation error, but got: %v", result.Error)
+	}
+}
+
+func ParseErrorOutput(t *testing.T, stderr string) map[string]interface{} {
+	if strings.Count(strings.TrimSpace(stderr), "\n") != 0 {
+		t.Errorf("Expected single line json error, but got: %v", stderr)
+	}
+	var result map[string]interface{}
+	err := json.Unmarshal([]byte(stderr), &result)
+	if err != nil {
+		t.Fatalf("Failed to deserialize error output: %v, got: %v", err, stderr)
+	}
+	return result
+}
+
+func AssertErrorOutput(t *testing.T, expected map[string]interface{}, actual map[string]interface{}) {
+	expectedJson, _ := json.Marshal(expected)
+	actualJson, _ := json.Marshal(actual)
+	if string(expectedJson) != string(actualJson) {
+		t.Errorf("Expected error output %v, but got: %v", string(expectedJson), string(actualJson))
+	}
+}
@@ -0,0 +1,201 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/UiPath/uipathcli/commandline"
+)
+
+func TestExitCodeSuccess(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != commandline.ExitCodeSuccess {
+		t.Errorf("Expected success exit code, got: %v", exitCode)
+	}
+}
+
+func TestExitCodeForHttpStatusCodes(t *testing.T) {
+	t.Run("Unauthorized", func(t *testing.T) {
+		ExitCodeForHttpStatusCode(t, http.StatusUnauthorized, commandline.ExitCodeAuthenticationError)
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		ExitCodeForHttpStatusCode(t, http.StatusForbidden, commandline.ExitCodeAuthenticationError)
+	})
+	t.Run("NotFound", func(t *testing.T) { ExitCodeForHttpStatusCode(t, http.StatusNotFound, commandline.ExitCodeNotFound) })
+	t.Run("Conflict", func(t *testing.T) { ExitCodeForHttpStatusCode(t, http.StatusConflict, commandline.ExitCodeConflict) })
+	t.Run("BadRequest", func(t *testing.T) {
+		ExitCodeForHttpStatusCode(t, http.StatusBadRequest, commandline.ExitCodeClientError)
+	})
+	t.Run("InternalServerError", func(t *testing.T) {
+		ExitCodeForHttpStatusCode(t, http.StatusInternalServerError, commandline.ExitCodeServerError)
+	})
+}
+
+func ExitCodeForHttpStatusCode(t *testing.T, statusCode int, expectedExitCode int) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(statusCode, `{"message":"error"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--max-attempts", "1"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != expectedExitCode {
+		t.Errorf("Expected exit code %v, got: %v", expectedExitCode, exitCode)
+	}
+}
+
+func TestExitCodeHttpErrorContainsStatusCodeAndBody(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	expected := `Service returned status code '404': Not found`
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected http error %v, got: %v", expected, result.Error)
+	}
+}
+
+func TestExitCodeNoFailOnHttpErrorReturnsSuccess(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--no-fail-on-http-error"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, got: %v", result.Error)
+	}
+	if result.StdOut != "{\n  \"message\": \"Not found\"\n}\n" {
+		t.Errorf("Expected response body on stdout, got: %v", result.StdOut)
+	}
+}
+
+func TestExitCodeForMissingArgument(t *testing.T) {
+	definition := `
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-user"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != commandline.ExitCodeValidationError {
+		t.Errorf("Expected validation error exit code, got: %v", exitCode)
+	}
+}
+
+func TestExitCodeForUnknownFlag(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--unknown", "value"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != commandline.ExitCodeValidationError {
+		t.Errorf("Expected validation error exit code, got: %v", exitCode)
+	}
+}
+
+func TestExitCodeForNetworkError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--uri", "http://127.0.0.1:1", "--max-attempts", "1"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != commandline.ExitCodeNetworkError {
+		t.Errorf("Expected network error exit code, got: %v (%v)", exitCode, result.Error)
+	}
+}
+
+func TestExitCodeForAuthenticationError(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  organization: my-org
+  tenant: my-tenant
+  auth:
+    clientId: my-client-id
+    clientSecret: my-client-secret
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithIdentityResponse(http.StatusBadRequest, `{"error":"invalid_client"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	exitCode := commandline.ExitCode(result.Error)
+	if exitCode != commandline.ExitCodeAuthenticationError {
+		t.Errorf("Expected authentication error exit code, got: %v (%v)", exitCode, result.Error)
+	}
+}
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const exportDefinition = `
+paths:
+  /assets/{id}:
+    post:
+      operationId: updateAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+      - name: filter
+        in: query
+        schema:
+          type: string
+      - name: x-uipath-folder
+        in: header
+        schema:
+          type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+`
+
+func TestExportCurlCommand(t *testing.T) {
+	requestCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			requestCount++
+			return ResponseData{Status: http.StatusOK, Body: ""}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--filter", "my-filter", "--x-uipath-folder", "Shared", "--name", "it's mine", "--export", "curl"}, context)
+
+	if requestCount != 0 {
+		t.Errorf("Expected no request to be sent, but got: %d", requestCount)
+	}
+	expected := `curl -X POST '` + result.BaseUrl + `/assets/1?filter=my-filter' \
+  -H 'Content-Type: application/json' \
+  -H 'X-Uipath-Folder: Shared' \
+  --data-raw '{"name":"it'\''s mine"}'
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected curl command %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestExportPowerShellCommand(t *testing.T) {
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--x-uipath-folder", "Shared", "--name", "it's mine", "--export", "powershell"}, context)
+
+	expected := "Invoke-RestMethod -Method POST `\n" +
+		"  -Uri '" + result.BaseUrl + "/assets/1' `\n" +
+		"  -Headers @{ 'X-Uipath-Folder' = 'Shared' } `\n" +
+		"  -ContentType 'application/json' `\n" +
+		"  -Body '{\"name\":\"it''s mine\"}'\n"
+	if result.StdOut != expected {
+		t.Errorf("Expected PowerShell command %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestExportHttpieCommand(t *testing.T) {
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--name", "my-asset", "--export", "httpie"}, context)
+
+	expected := `http POST '` + result.BaseUrl + `/assets/1' \
+  'Content-Type:application/json' \
+  --raw '{"name":"my-asset"}'
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected httpie command %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestExportIncludesConfigHeaders(t *testing.T) {
+	config := `
+profiles:
+  - name: default
+    header:
+      X-UIPATH-License: my-license
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--export", "curl"}, context)
+
+	if !strings.Contains(result.StdOut, "-H 'X-Uipath-License: my-license'") {
+		t.Errorf("Expected config header in curl command, got: %v", result.StdOut)
+	}
+}
+
+func TestExportMultipartFormReferencesFile(t *testing.T) {
+	definition := `
+paths:
+  /upload:
+    post:
+      operationId: upload
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file:
+                  type: string
+                  format: binary
+                description:
+                  type: string
+`
+	path := CreateTempFile(t, "hello-world")
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "upload", "--file", path, "--description", "@@not-a-file", "--export", "curl"}, context)
+
+	expected := `curl -X POST '` + result.BaseUrl + `/upload' \
+  --form-string 'description=@not-a-file' \
+  -F 'file=@` + path + `'
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected curl command with file reference %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestExportFileInputReferencesFile(t *testing.T) {
+	definition := `
+paths:
+  /upload:
+    put:
+      operationId: upload
+      requestBody:
+        content:
+          application/octet-stream:
+            schema:
+              type: string
+              format: binary
+`
+	path := CreateTempFile(t, "hello-world")
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "upload", "--file", path, "--export", "httpie"}, context)
+
+	expected := `http PUT '` + result.BaseUrl + `/upload' \
+  'Content-Type:application/octet-stream' \
+  < '` + path + `'
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected httpie command with file input %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestExportTokenIncludesBearerToken(t *testing.T) {
+	config := `
+profiles:
+  - name: default
+    auth:
+      pat: rt_mypat
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--export", "curl", "--export-token"}, context)
+
+	if !strings.Contains(result.StdOut, "-H 'Authorization: Bearer rt_mypat'") {
+		t.Errorf("Expected bearer token in curl command, got: %v", result.StdOut)
+	}
+}
+
+func TestExportWithoutTokenDoesNotAuthenticate(t *testing.T) {
+	config := `
+profiles:
+  - name: default
+    auth:
+      pat: rt_mypat
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--export", "curl"}, context)
+
+	if strings.Contains(result.StdOut, "Authorization") {
+		t.Errorf("Expected no authorization header in curl command, got: %v", result.StdOut)
+	}
+}
+
+func TestExportInvalidFormatReturnsError(t *testing.T) {
+	context := NewContextBuilder().
+		WithDefinition("myservice", exportDefinition).
+		WithResponse(http.StatusOK, "").
+		Build()
+
+	result := RunCli([]string{"myservice", "update-asset", "--id", "1", "--export", "wget"}, context)
+
+	expected := "Invalid export format 'wget', allowed values: curl, powershell, httpie"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected invalid export format error %v, got: %v", expected, result.Error)
+	}
+}
@@ -0,0 +1,166 @@
+package test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const fileReferenceDefinition = `
+paths:
+  /validate:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              properties:
+                name:
+                  type: string
+                settings:
+                  type: object
+                  properties:
+                    size:
+                      type: integer
+                items:
+                  type: array
+                  items:
+                    type: object
+                tags:
+                  type: array
+                  items:
+                    type: string
+`
+
+func TestStringArgumentReadsValueFromFile(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+	path := CreateTempFile(t, "my-name")
+
+	result := RunCli([]string{"myservice", "post-validate", "--name", "@" + path}, context)
+
+	expected := `{"name":"my-name"}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find file content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestObjectArgumentReadsJsonFromFile(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+	path := CreateTempFile(t, `{"size": 5, "enabled": true}`)
+
+	result := RunCli([]string{"myservice", "post-validate", "--settings", "@" + path}, context)
+
+	expected := `{"settings":{"enabled":true,"size":5}}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find file content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestObjectArgumentReadsAssignmentsFromFile(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+	path := CreateTempFile(t, "size=5;name=foo")
+
+	result := RunCli([]string{"myservice", "post-validate", "--settings", "@" + path}, context)
+
+	expected := `{"settings":{"name":"foo","size":5}}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not convert file content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestObjectArrayArgumentReadsItemsFromFiles(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+	path := CreateTempFile(t, `{"id": 1}`)
+
+	result := RunCli([]string{"myservice", "post-validate", "--items", "@" + path, "--items", `{"id": 2}`}, context)
+
+	expected := `{"items":[{"id":1},{"id":2}]}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find file content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestStringArrayArgumentReadsValuesFromFiles(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+	path := CreateTempFile(t, "a,b")
+
+	result := RunCli([]string{"myservice", "post-validate", "--tags", "@" + path, "--tags", "c"}, context)
+
+	expected := `{"tags":["a","b","c"]}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find file content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestStringArrayArgumentReadsValuesFromStdIn(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		WithStdIn(*bytes.NewBufferString("a,b")).
+		Build()
+
+	result := RunCli([]string{"myservice", "post-validate", "--tags", "@-"}, context)
+
+	expected := `{"tags":["a","b"]}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find stdin content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestObjectArgumentReadsJsonFromStdIn(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		WithStdIn(*bytes.NewBufferString(`{"size": 7}`)).
+		Build()
+
+	result := RunCli([]string{"myservice", "post-validate", "--settings", "@-"}, context)
+
+	expected := `{"settings":{"size":7}}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find stdin content in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestDoubleAtSignPassesLiteralValue(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+
+	result := RunCli([]string{"myservice", "post-validate", "--name", "@@my-name"}, context)
+
+	expected := `{"name":"@my-name"}`
+	if result.RequestBody != expected {
+		t.Errorf("Did not find literal value in request body, expected: %v, got: %v", expected, result.RequestBody)
+	}
+}
+
+func TestFileReferenceNotFoundShowsValidationError(t *testing.T) {
+	context := NewContextBuilder().
+		WithResponse(http.StatusOK, "{}").
+		WithDefinition("myservice", fileReferenceDefinition).
+		Build()
+
+	result := RunCli([]string{"myservice", "post-validate", "--settings", "@does-not-exist.json"}, context)
+
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "File 'does-not-exist.json' not found") {
+		t.Errorf("Expected file not found error, but got: %v", result.Error)
+	}
+}
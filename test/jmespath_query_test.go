@@ -100,3 +100,29 @@ paths:
 		t.Errorf("Expected response body on stdout %v, got: %v", expectedStdOut, result.StdOut)
 	}
 }
+
+func TestQuerySupportsCustomFunctions(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"value":[{"Name":"db","Value":"eyJwb3J0Ijo1NDMyfQ=="},{"Name":"other","Value":"e30="}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--query", "value[?Name == 'db'] | [0].{name: to_upper(Name), port: parse_json(base64_decode(Value)).port}"}, context)
+
+	expectedStdOut := `{
+  "name": "DB",
+  "port": 5432
+}
+`
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected response body on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
@@ -0,0 +1,226 @@
+package test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestOutputFileWritesBinaryResponse(t *testing.T) {
+	definition := `
+paths:
+  /export:
+    get:
+      operationId: export
+`
+	body := string([]byte{0x00, 0x01, 0x02, 0xff})
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			return ResponseData{Status: http.StatusOK, Body: body, Header: map[string]string{"Content-Type": "application/zip"}}
+		}).
+		Build()
+
+	path := TempFile(t)
+	result := RunCli([]string{"myservice", "export", "--output-file", path}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if result.StdOut != "" {
+		t.Errorf("Expected stdout to be empty, but got: %v", result.StdOut)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != body {
+		t.Errorf("Expected output file to contain response body, but got: %v", data)
+	}
+}
+
+func TestOutputFileFormatsJsonResponse(t *testing.T) {
+	definition := `
+paths:
+  /users:
+    get:
+      operationId: getUsers
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			return ResponseData{Status: http.StatusOK, Body: `{"value":[{"name":"user1"},{"name":"user2"}]}`, Header: map[string]string{"Content-Type": "application/json; charset=utf-8"}}
+		}).
+		Build()
+
+	path := TempFile(t)
+	result := RunCli([]string{"myservice", "get-users", "--output-file", path, "--query", "value[].name", "--output", "text"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "user1\nuser2\n" {
+		t.Errorf("Expected output file to contain transformed response, but got: %v", string(data))
+	}
+}
+
+func TestOutputFileReplacesExistingFile(t *testing.T) {
+	definition := `
+paths:
+  /export:
+    get:
+      operationId: export
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "new content").
+		Build()
+
+	path := CreateTempFile(t, "old content which is longer")
+	RunCli([]string{"myservice", "export", "--output-file", path}, context)
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "new content" {
+		t.Errorf("Expected output file to be replaced, but got: %v", string(data))
+	}
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("Expected temporary file to be removed, but got: %v", files)
+	}
+}
+
+func TestOutputFileKeepsExistingFileOnError(t *testing.T) {
+	definition := `
+paths:
+  /users:
+    get:
+      operationId: getUsers
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			return ResponseData{Status: http.StatusOK, Body: `{"value":[]}`, Header: map[string]string{"Content-Type": "application/json"}}
+		}).
+		Build()
+
+	path := CreateTempFile(t, "old content")
+	result := RunCli([]string{"myservice", "get-users", "--output-file", path, "--query", "invalid query("}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected error for invalid query, but got none")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "old content" {
+		t.Errorf("Expected output file to be unchanged, but got: %v", string(data))
+	}
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("Expected temporary file to be removed, but got: %v", files)
+	}
+}
+
+func TestOutputFileKeepsExistingFileOnErrorResponse(t *testing.T) {
+	definition := `
+paths:
+  /export:
+    get:
+      operationId: export
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	path := CreateTempFile(t, "old content")
+	result := RunCli([]string{"myservice", "export", "--output-file", path}, context)
+
+	if result.Error == nil || result.Error.Error() != "Service returned status code '404': Not found" {
+		t.Errorf("Expected service error, but got: %v", result.Error)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "old content" {
+		t.Errorf("Expected output file to be unchanged, but got: %v", string(data))
+	}
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("Expected no temporary file to be left, but got: %v", files)
+	}
+}
+
+func TestOutputFileNoFailOnHttpErrorWritesBodyToStandardError(t *testing.T) {
+	definition := `
+paths:
+  /export:
+    get:
+      operationId: export
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Not found"}`).
+		Build()
+
+	path := CreateTempFile(t, "old content")
+	result := RunCli([]string{"myservice", "export", "--output-file", path, "--no-fail-on-http-error"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error, but got: %v", result.Error)
+	}
+	if result.StdErr != "{\"message\":\"Not found\"}\n" {
+		t.Errorf("Expected response body on stderr, but got: %v", result.StdErr)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "old content" {
+		t.Errorf("Expected output file to be unchanged, but got: %v", string(data))
+	}
+}
+
+func TestOutputFileUsesDefaultFilePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("File permissions are not supported on windows")
+	}
+	definition := `
+paths:
+  /export:
+    get:
+      operationId: export
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "content").
+		Build()
+
+	directory := t.TempDir()
+	reference := filepath.Join(directory, "reference.txt")
+	_ = os.WriteFile(reference, []byte{}, 0644)
+	path := filepath.Join(directory, "export.zip")
+	RunCli([]string{"myservice", "export", "--output-file", path}, context)
+
+	expected, _ := os.Stat(reference)
+	actual, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected output file to be created, but got: %v", err)
+	}
+	if actual.Mode().Perm() != expected.Mode().Perm() {
+		t.Errorf("Expected output file permissions %v, but got: %v", expected.Mode().Perm(), actual.Mode().Perm())
+	}
+}
+
+func TestOutputFileInvalidDirectoryReturnsError(t *testing.T) {
+	definition := `
+paths:
+  /export:
+    get:
+      operationId: export
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, "content").
+		Build()
+
+	path := filepath.Join(t.TempDir(), "not-found", "export.zip")
+	result := RunCli([]string{"myservice", "export", "--output-file", path}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected error for invalid output file, but got none")
+	}
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -266,3 +267,233 @@ paths:
 		t.Errorf("Expected boolean on stdout %v, got: %v", expectedStdOut, result.StdOut)
 	}
 }
+
+func TestTableOutputPrintsColumnsWithHeader(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"@odata.count":2,"value":[{"Name":"Job 1","State":"Running","StartTime":"2024-01-01"},{"Name":"Job 2","State":"Successful","StartTime":null}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "table", "--columns", "Name,State,StartTime"}, context)
+
+	expectedStdOut := "Name   State       StartTime\n" +
+		"Job 1  Running     2024-01-01\n" +
+		"Job 2  Successful\n"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected table on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestTableOutputAppliesQueryBeforeFormatting(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"value":[{"Name":"a","State":"Running"},{"Name":"b","State":"Faulted"}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "table", "--query", "value[?State == 'Faulted']"}, context)
+
+	expectedStdOut := "Name  State\nb     Faulted\n"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected table on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestInvalidOutputFormatShowsAllowedValues(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "invalid"}, context)
+
+	expected := "Invalid output format 'invalid', allowed values: json, text, table, csv, tsv, yaml, template, ndjson"
+	if !strings.Contains(result.StdErr, expected) {
+		t.Errorf("Expected error %v on stderr, got: %v", expected, result.StdErr)
+	}
+}
+
+func TestCsvOutputPrintsFlattenedRows(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"@odata.count":2,"value":[{"Name":"Job, 1","State":"Running","Robot":{"Name":"r1"}},{"Name":"Job 2","State":"Successful"}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "csv", "--columns", "Name,Robot.Name,State"}, context)
+
+	expectedStdOut := "Name,Robot.Name,State\n" +
+		"\"Job, 1\",r1,Running\n" +
+		"Job 2,,Successful\n"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected csv on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestYamlOutputPrintsSortedKeys(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"value":[{"Name":"a","Id":1},{"Name":"b","Id":2}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "yaml", "--query", "value[?Id == `2`]"}, context)
+
+	expectedStdOut := "- Id: 2\n  Name: b\n"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected yaml on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestYamlOutputFromProfileConfig(t *testing.T) {
+	config := `
+profiles:
+  - name: default
+    output: yaml
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{"b":"bar","a":"foo"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping"}, context)
+
+	expectedStdOut := "a: foo\nb: bar\n"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected yaml on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestTemplateOutputFormatsResponse(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"value":[{"Name":"Job 1","State":"Running"},{"Name":"Job 2","State":"Faulted"}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "template", "--template", `{{range .value}}{{.Name}}: {{.State}}{{"\n"}}{{end}}`}, context)
+
+	expectedStdOut := "Job 1: Running\nJob 2: Faulted\n"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected template output on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestTemplateOutputReadsTemplateFileAndAppliesQuery(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+	path := CreateTempFile(t, `{{range .}}{{.Name | padRight 6}}|{{end}}`)
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"value":[{"Name":"a","Id":1},{"Name":"b","Id":2}]}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "template", "--template-file", path, "--query", "value[?Id == `2`]"}, context)
+
+	expectedStdOut := "b     |"
+	if result.StdOut != expectedStdOut {
+		t.Errorf("Expected template output on stdout %v, got: %v", expectedStdOut, result.StdOut)
+	}
+}
+
+func TestTemplateOutputWithoutTemplateShowsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "template"}, context)
+
+	expected := "Argument --template or --template-file is required for template output"
+	if !strings.Contains(result.StdErr, expected) {
+		t.Errorf("Expected error %v on stderr, got: %v", expected, result.StdErr)
+	}
+}
+
+func TestTemplateOutputWithInvalidTemplateShowsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      summary: Simple ping
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--output", "template", "--template", "{{.Name"}, context)
+
+	if !strings.Contains(result.StdErr, "Invalid template:") {
+		t.Errorf("Expected invalid template error on stderr, got: %v", result.StdErr)
+	}
+}
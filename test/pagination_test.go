@@ -0,0 +1,348 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPaginateRetrievesAllPagesUsingOdataCount(t *testing.T) {
+	definition := `
+paths:
+  /jobs:
+    get:
+      operationId: getJobs
+      parameters:
+      - name: $skip
+        in: query
+        schema:
+          type: integer
+`
+
+	requests := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			requests = append(requests, request.URL.Query().Encode())
+			skip := request.URL.Query().Get("$skip")
+			switch skip {
+			case "":
+				return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":5,"value":[{"Id":1},{"Id":2}]}`}
+			case "2":
+				return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":5,"value":[{"Id":3},{"Id":4}]}`}
+			case "4":
+				return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":5,"value":[{"Id":5}]}`}
+			}
+			return ResponseData{Status: http.StatusBadRequest, Body: "Unexpected skip " + skip}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-jobs", "--paginate", "--query", "value[].Id"}, context)
+
+	expected := `[
+  1,
+  2,
+  3,
+  4,
+  5
+]
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected all pages on stdout %v, got: %v", expected, result.StdOut)
+	}
+	if len(requests) != 3 {
+		t.Errorf("Expected 3 requests, but got: %v", requests)
+	}
+	if requests[0] != "%24count=true" {
+		t.Errorf("Expected first request to include $count, but got: %v", requests[0])
+	}
+}
+
+func TestPaginateStartsAtProvidedSkip(t *testing.T) {
+	definition := `
+paths:
+  /jobs:
+    get:
+      operationId: getJobs
+      parameters:
+      - name: $skip
+        in: query
+        schema:
+          type: integer
+`
+
+	skips := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			skip := request.URL.Query().Get("$skip")
+			skips = append(skips, skip)
+			if skip == "3" {
+				return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":5,"value":[{"Id":4}]}`}
+			}
+			return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":5,"value":[{"Id":5}]}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-jobs", "--paginate", "--skip", "3"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if fmt.Sprint(skips) != "[3 4]" {
+		t.Errorf("Expected requests with skip 3 and 4, but got: %v", skips)
+	}
+}
+
+func TestPaginateFollowsNextLink(t *testing.T) {
+	definition := `
+paths:
+  /jobs:
+    get:
+      operationId: getJobs
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			if request.URL.Query().Get("page") == "2" {
+				return ResponseData{Status: http.StatusOK, Body: `{"value":[{"Id":2}]}`}
+			}
+			return ResponseData{Status: http.StatusOK, Body: `{"value":[{"Id":1}],"@odata.nextLink":"/jobs?page=2"}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-jobs", "--paginate"}, context)
+
+	expected := `{
+  "value": [
+    {
+      "Id": 1
+    },
+    {
+      "Id": 2
+    }
+  ]
+}
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected merged pages on stdout %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestPaginateStopsOnErrorResponse(t *testing.T) {
+	definition := `
+paths:
+  /jobs:
+    get:
+      operationId: getJobs
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			if request.URL.Query().Get("$skip") == "1" {
+				return ResponseData{Status: http.StatusBadRequest, Body: `{"message":"Invalid skip"}`}
+			}
+			return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":2,"value":[{"Id":1}]}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-jobs", "--paginate"}, context)
+
+	expected := `{
+  "message": "Invalid skip"
+}
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected error response on stdout %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestPaginateWithoutOdataResponseReturnsBody(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"hello":"world"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--paginate"}, context)
+
+	expected := `{
+  "hello": "world"
+}
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected response body on stdout %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestPaginateUsesContinuationTokenFromDefinition(t *testing.T) {
+	definition := `
+paths:
+  /files:
+    get:
+      operationId: listFiles
+      x-uipathcli-pagination:
+        tokenParameter: continuationToken
+        tokenField: continuationToken
+        itemsField: items
+      parameters:
+      - name: continuationToken
+        in: query
+        schema:
+          type: string
+`
+
+	tokens := []string{}
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			token := request.URL.Query().Get("continuationToken")
+			tokens = append(tokens, token)
+			switch token {
+			case "":
+				return ResponseData{Status: http.StatusOK, Body: `{"items":[{"name":"a.txt"}],"continuationToken":"page2"}`}
+			case "page2":
+				return ResponseData{Status: http.StatusOK, Body: `{"items":[{"name":"b.txt"}],"continuationToken":"page3"}`}
+			case "page3":
+				return ResponseData{Status: http.StatusOK, Body: `{"items":[{"name":"c.txt"}],"continuationToken":null}`}
+			}
+			return ResponseData{Status: http.StatusBadRequest, Body: "Unexpected token " + token}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "list-files", "--paginate"}, context)
+
+	expected := `{
+  "items": [
+    {
+      "name": "a.txt"
+    },
+    {
+      "name": "b.txt"
+    },
+    {
+      "name": "c.txt"
+    }
+  ]
+}
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected merged pages on stdout %v, got: %v", expected, result.StdOut)
+	}
+	if fmt.Sprint(tokens) != "[ page2 page3]" {
+		t.Errorf("Expected requests with continuation tokens, but got: %v", tokens)
+	}
+}
+
+func TestContinuationTokenPaginationRequiresPaginateFlag(t *testing.T) {
+	definition := `
+paths:
+  /files:
+    get:
+      operationId: listFiles
+      x-uipathcli-pagination:
+        tokenParameter: continuationToken
+        tokenField: continuationToken
+        itemsField: items
+`
+
+	requestCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			requestCount++
+			return ResponseData{Status: http.StatusOK, Body: `{"items":[{"name":"a.txt"}],"continuationToken":"page2"}`}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "list-files", "--query", "continuationToken"}, context)
+
+	if result.StdOut != "\"page2\"\n" {
+		t.Errorf("Expected single page on stdout, got: %v", result.StdOut)
+	}
+	if requestCount != 1 {
+		t.Errorf("Expected a single request, but got: %d", requestCount)
+	}
+}
+
+func TestPaginateWithNdJsonOutputWritesEveryPage(t *testing.T) {
+	definition := `
+paths:
+  /jobs:
+    get:
+      operationId: getJobs
+      parameters:
+      - name: $skip
+        in: query
+        schema:
+          type: integer
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			skip := request.URL.Query().Get("$skip")
+			switch skip {
+			case "":
+				return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":3,"value":[{"Id":1},{"Id":2}]}`}
+			case "2":
+				return ResponseData{Status: http.StatusOK, Body: `{"@odata.count":3,"value":[{"Id":3}]}`}
+			}
+			return ResponseData{Status: http.StatusBadRequest, Body: "Unexpected skip " + skip}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-jobs", "--paginate", "--output", "ndjson"}, context)
+
+	expected := "{\"Id\":1}\n{\"Id\":2}\n{\"Id\":3}\n"
+	if result.StdOut != expected {
+		t.Errorf("Expected one line per item on stdout %v, got: %v", expected, result.StdOut)
+	}
+}
+
+func TestPaginateWithTokenAndNdJsonOutputAppliesQueryPerPage(t *testing.T) {
+	definition := `
+paths:
+  /items:
+    get:
+      operationId: getItems
+      x-uipathcli-pagination:
+        tokenParameter: continuationToken
+        tokenField: continuationToken
+        itemsField: items
+      parameters:
+      - name: continuationToken
+        in: query
+        schema:
+          type: string
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			token := request.URL.Query().Get("continuationToken")
+			switch token {
+			case "":
+				return ResponseData{Status: http.StatusOK, Body: `{"items":[{"id":"a"},{"id":"b"}],"continuationToken":"next"}`}
+			case "next":
+				return ResponseData{Status: http.StatusOK, Body: `{"items":[{"id":"c"}]}`}
+			}
+			return ResponseData{Status: http.StatusBadRequest, Body: "Unexpected token " + token}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-items", "--paginate", "--output", "ndjson", "--query", "items[].id"}, context)
+
+	expected := "\"a\"\n\"b\"\n\"c\"\n"
+	if result.StdOut != expected {
+		t.Errorf("Expected one line per item on stdout %v, got: %v", expected, result.StdOut)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/UiPath/uipathcli/commandline"
 	"github.com/UiPath/uipathcli/log"
 	"github.com/UiPath/uipathcli/output"
 	"github.com/UiPath/uipathcli/plugin"
@@ -213,6 +214,102 @@ profiles:
 	}
 }
 
+func TestPluginContextDryRunFlag(t *testing.T) {
+	pluginCommand := DryRunPluginCommand{}
+	context := NewContextBuilder().
+		WithDefinition("mypluginservice", "").
+		WithCommandPlugin(&pluginCommand).
+		Build()
+
+	RunCli([]string{"mypluginservice", "my-dry-run-command", "--dry-run"}, context)
+
+	if !pluginCommand.Context.DryRun {
+		t.Errorf("Expected dry run flag to be true, but got: %v", pluginCommand.Context.DryRun)
+	}
+}
+
+func TestPluginDryRunNotSupportedShowsValidationError(t *testing.T) {
+	pluginCommand := ContextPluginCommand{}
+	context := NewContextBuilder().
+		WithDefinition("mypluginservice", "").
+		WithCommandPlugin(&pluginCommand).
+		Build()
+
+	result := RunCli([]string{"mypluginservice", "my-plugin-command", "--dry-run"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Dry run is not supported for this command" {
+		t.Errorf("Expected dry run not supported error, but got: %v", result.Error)
+	}
+	if commandline.ExitCode(result.Error) != commandline.ExitCodeValidationError {
+		t.Errorf("Expected validation error exit code, but got: %v", commandline.ExitCode(result.Error))
+	}
+	if pluginCommand.Context.DryRun {
+		t.Errorf("Expected plugin command not to be executed")
+	}
+}
+
+func TestPluginDryRunDoesNotAuthenticate(t *testing.T) {
+	pluginCommand := DryRunPluginCommand{Authenticate: false}
+	RunDryRunPluginCommand(&pluginCommand, "--dry-run")
+
+	if pluginCommand.Context.Auth.Token != nil {
+		t.Errorf("Expected no auth token during dry run, but got: %v", pluginCommand.Context.Auth.Token)
+	}
+}
+
+func TestPluginDryRunAuthFlagAuthenticates(t *testing.T) {
+	pluginCommand := DryRunPluginCommand{Authenticate: false}
+	RunDryRunPluginCommand(&pluginCommand, "--dry-run", "--dry-run-auth")
+
+	if pluginCommand.Context.Auth.Token == nil || pluginCommand.Context.Auth.Token.Value != "my-jwt-access-token" {
+		t.Errorf("Expected auth token during dry run with --dry-run-auth, but got: %v", pluginCommand.Context.Auth.Token)
+	}
+}
+
+func TestPluginDryRunAuthenticatesWhenRequestedByCommand(t *testing.T) {
+	pluginCommand := DryRunPluginCommand{Authenticate: true}
+	RunDryRunPluginCommand(&pluginCommand, "--dry-run")
+
+	if pluginCommand.Context.Auth.Token == nil || pluginCommand.Context.Auth.Token.Value != "my-jwt-access-token" {
+		t.Errorf("Expected auth token during dry run, but got: %v", pluginCommand.Context.Auth.Token)
+	}
+}
+
+func RunDryRunPluginCommand(pluginCommand *DryRunPluginCommand, args ...string) Result {
+	config := `
+profiles:
+- name: default
+  auth:
+    clientId: very
+    clientSecret: short
+`
+	context := NewContextBuilder().
+		WithDefinition("mypluginservice", "").
+		WithConfig(config).
+		WithCommandPlugin(pluginCommand).
+		WithResponse(http.StatusOK, "").
+		WithIdentityResponse(http.StatusOK, `{"access_token": "my-jwt-access-token", "expires_in": 3600, "token_type": "Bearer", "scope": "OR.Ping"}`).
+		Build()
+
+	return RunCli(append([]string{"mypluginservice", "my-dry-run-command"}, args...), context)
+}
+
+func TestPluginExportNotSupported(t *testing.T) {
+	context := NewContextBuilder().
+		WithDefinition("mypluginservice", "").
+		WithCommandPlugin(SimplePluginCommand{}).
+		Build()
+
+	result := RunCli([]string{"mypluginservice", "my-plugin-command", "--export", "curl"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Export is not supported for this command" {
+		t.Errorf("Expected export not supported error, but got: %v", result.Error)
+	}
+	if commandline.ExitCode(result.Error) != commandline.ExitCodeValidationError {
+		t.Errorf("Expected validation error exit code, but got: %v", commandline.ExitCode(result.Error))
+	}
+}
+
 func TestPluginContextParameterValue(t *testing.T) {
 	pluginCommand := ContextPluginCommand{}
 	context := NewContextBuilder().
@@ -357,6 +454,22 @@ func (c *ContextPluginCommand) Execute(ctx plugin.ExecutionContext, writer outpu
 	return nil
 }
 
+type DryRunPluginCommand struct {
+	Authenticate bool
+	Context      plugin.ExecutionContext
+}
+
+func (c *DryRunPluginCommand) Command() plugin.Command {
+	return *plugin.NewCommand("mypluginservice").
+		WithOperation("my-dry-run-command", "Dry Run Command", "This command supports dry run").
+		WithDryRun(c.Authenticate)
+}
+
+func (c *DryRunPluginCommand) Execute(ctx plugin.ExecutionContext, writer output.OutputWriter, logger log.Logger) error {
+	c.Context = ctx
+	return nil
+}
+
 type ErrorPluginCommand struct{}
 
 func (c ErrorPluginCommand) Command() plugin.Command {
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimitDelaysRequests(t *testing.T) {
+	definition := `
+paths:
+  /assets/{id}:
+    get:
+      operationId: getAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	path := CreateTempFile(t, "{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n{\"id\": 4}\n")
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	start := time.Now()
+	result := RunCli([]string{"myservice", "get-asset", "--batch", path, "--batch-concurrency", "4", "--rate-limit", "10", "--rate-limit-burst", "1"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 300*time.Millisecond {
+		t.Errorf("Expected requests to be rate limited, took: %v", elapsed)
+	}
+}
+
+func TestRateLimitUsesSettingsFromProfile(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  rateLimit:
+    requestsPerSecond: 100
+    burst: 100
+    hosts:
+      127.0.0.1:
+        requestsPerSecond: 5
+        burst: 1
+`
+	definition := `
+paths:
+  /assets/{id}:
+    get:
+      operationId: getAsset
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+`
+	path := CreateTempFile(t, "{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n")
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	start := time.Now()
+	result := RunCli([]string{"myservice", "get-asset", "--batch", path, "--batch-concurrency", "3"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 400*time.Millisecond {
+		t.Errorf("Expected requests to be rate limited by host setting, took: %v", elapsed)
+	}
+}
+
+func TestRateLimitAppliesToTokenRequests(t *testing.T) {
+	config := fmt.Sprintf(`
+profiles:
+  - name: default
+    auth:
+      clientId: rate-limit-client-id-%d
+      clientSecret: rate-limit-client-secret
+`, time.Now().UnixNano())
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		WithIdentityResponse(http.StatusOK, `{"access_token": "my-jwt-access-token", "expires_in": 3600, "token_type": "Bearer"}`).
+		Build()
+
+	start := time.Now()
+	result := RunCli([]string{"myservice", "ping", "--rate-limit", "2", "--rate-limit-burst", "1"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 400*time.Millisecond {
+		t.Errorf("Expected token request to be rate limited, took: %v", elapsed)
+	}
+}
+
+func TestRateLimitInvalidValueShowsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--rate-limit", "invalid"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Invalid value for 'rate-limit'" {
+		t.Errorf("Expected invalid value error, got: %v", result.Error)
+	}
+}
@@ -0,0 +1,286 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetriesTooManyRequestsUntilSuccess(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			if callCount == 2 {
+				return ResponseData{Status: http.StatusOK, Body: `{}`}
+			}
+			return ResponseData{Status: http.StatusTooManyRequests, Body: "Too Many Requests"}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--retry-initial-delay", "1ms"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error after retries, got: %v", result.Error)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 requests, got: %v", callCount)
+	}
+}
+
+func TestRetriesRequestTimeout(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			return ResponseData{Status: http.StatusRequestTimeout, Body: "Request Timeout"}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--retry-initial-delay", "1ms"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Service returned status code '408' and body 'Request Timeout'" {
+		t.Errorf("Expected request timeout error, got: %v", result.Error)
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 requests, got: %v", callCount)
+	}
+}
+
+func TestRetryHonorsRetryAfterHeader(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			if callCount == 2 {
+				return ResponseData{Status: http.StatusOK, Body: `{}`}
+			}
+			return ResponseData{Status: http.StatusTooManyRequests, Body: "", Header: map[string]string{"Retry-After": "1"}}
+		}).
+		Build()
+
+	start := time.Now()
+	result := RunCli([]string{"myservice", "ping", "--retry-initial-delay", "1ms"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error after retries, got: %v", result.Error)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 1*time.Second {
+		t.Errorf("Expected to wait for Retry-After header, waited: %v", elapsed)
+	}
+}
+
+func TestRetryLimitsRetryAfterHeaderToMaxDelay(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			if callCount == 2 {
+				return ResponseData{Status: http.StatusOK, Body: `{}`}
+			}
+			return ResponseData{Status: http.StatusTooManyRequests, Body: "", Header: map[string]string{"Retry-After": "3600"}}
+		}).
+		Build()
+
+	start := time.Now()
+	result := RunCli([]string{"myservice", "ping", "--retry-initial-delay", "1ms", "--retry-max-delay", "10ms"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Expected no error after retries, got: %v", result.Error)
+	}
+	elapsed := time.Since(start)
+	if elapsed > 5*time.Second {
+		t.Errorf("Expected Retry-After header to be limited to max delay, waited: %v", elapsed)
+	}
+}
+
+func TestRetryDoesNotRetryNonIdempotentRequests(t *testing.T) {
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			return ResponseData{Status: http.StatusServiceUnavailable, Body: "Service Unavailable"}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-asset", "--retry-initial-delay", "1ms"}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected service error, got none")
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 request, got: %v", callCount)
+	}
+}
+
+func TestRetryNonIdempotentRequestsWhenEnabled(t *testing.T) {
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			return ResponseData{Status: http.StatusServiceUnavailable, Body: "Service Unavailable"}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-asset", "--retry-initial-delay", "1ms", "--retry-non-idempotent"}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected service error, got none")
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 requests, got: %v", callCount)
+	}
+}
+
+func TestRetryUsesSettingsFromProfile(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  retry:
+    maxAttempts: 5
+    initialDelay: 1ms
+    maxDelay: 5ms
+    multiplier: 1.5
+    jitter: 0
+    nonIdempotent: true
+`
+	definition := `
+paths:
+  /assets:
+    post:
+      operationId: createAsset
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			return ResponseData{Status: http.StatusInternalServerError, Body: "Internal Server Error"}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-asset"}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected service error, got none")
+	}
+	if callCount != 5 {
+		t.Errorf("Expected 5 requests, got: %v", callCount)
+	}
+}
+
+func TestRetryFlagsOverrideProfileSettings(t *testing.T) {
+	config := `
+profiles:
+- name: default
+  retry:
+    maxAttempts: 5
+    initialDelay: 1ms
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			return ResponseData{Status: http.StatusInternalServerError, Body: "Internal Server Error"}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--max-attempts", "2"}, context)
+
+	if result.Error == nil {
+		t.Errorf("Expected service error, got none")
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 requests, got: %v", callCount)
+	}
+}
+
+func TestRetryInvalidDelayShowsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--retry-initial-delay", "invalid"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Invalid value for 'retry-initial-delay'" {
+		t.Errorf("Expected invalid value error, got: %v", result.Error)
+	}
+}
+
+func TestRetryInvalidJitterShowsError(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--retry-jitter", "2"}, context)
+
+	if result.Error == nil || result.Error.Error() != "Invalid value for 'retry-jitter'" {
+		t.Errorf("Expected invalid value error, got: %v", result.Error)
+	}
+}
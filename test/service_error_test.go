@@ -0,0 +1,257 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServiceErrorShowsMessageErrorCodeAndTraceId(t *testing.T) {
+	definition := `
+paths:
+  /odata/Folders:
+    get:
+      operationId: get-folders
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusBadRequest, `{"message":"The folder does not exist.","errorCode":1100,"traceId":"00-7b0a3b0c-00"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-folders"}, context)
+
+	expected := "Service returned status code '400': The folder does not exist. (error code: 1100, trace id: 00-7b0a3b0c-00)"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected concise service error %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorStillWritesResponseBodyToStandardOutput(t *testing.T) {
+	definition := `
+paths:
+  /odata/Folders:
+    get:
+      operationId: get-folders
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusBadRequest, `{"message":"The folder does not exist.","errorCode":1100}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-folders"}, context)
+
+	expected := `{
+  "errorCode": 1100,
+  "message": "The folder does not exist."
+}
+`
+	if result.StdOut != expected {
+		t.Errorf("Expected response body on standard output, but got: %v", result.StdOut)
+	}
+}
+
+func TestServiceErrorShowsScopesFromDescriptionOnForbidden(t *testing.T) {
+	definition := `
+paths:
+  /odata/Folders:
+    get:
+      operationId: get-folders
+      description: |-
+        OAuth required scopes: OR.Folders or OR.Folders.Read.
+
+        Required permissions: Units.View.
+      security:
+        - OAuth2: []
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusForbidden, `{"message":"You are not authorized!","errorCode":0,"traceId":"00-d1d2f4c4-00"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-folders"}, context)
+
+	expected := `Service returned status code '403': You are not authorized! (error code: 0, trace id: 00-d1d2f4c4-00)
+Hint: Make sure your credentials have been granted the OAuth scopes for this operation: one of: OR.Folders, OR.Folders.Read`
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected service error with scopes hint %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorShowsCombinedScopesFromDescription(t *testing.T) {
+	definition := `
+paths:
+  /tasks:
+    get:
+      operationId: get-tasks
+      description: 'OAuth required scopes: ((JamJamApi or JamJamApi.Read) and (RCS.FolderAuthorization or RCS.FolderAuthorization.Read)) and (OR.Tasks or OR.Tasks.Read).'
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusForbidden, `{"message":"You are not authorized!"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-tasks"}, context)
+
+	expected := `Service returned status code '403': You are not authorized!
+Hint: Make sure your credentials have been granted the OAuth scopes for this operation: (one of: JamJamApi, JamJamApi.Read) and (one of: RCS.FolderAuthorization, RCS.FolderAuthorization.Read) and (one of: OR.Tasks, OR.Tasks.Read)`
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected service error with scopes hint %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorShowsAlternativeScopesFromSecurityRequirements(t *testing.T) {
+	definition := `
+paths:
+  /odata/Folders:
+    get:
+      operationId: get-folders
+      security:
+        - OAuth2:
+            - OR.Folders
+        - OAuth2:
+            - OR.Folders.Read
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusForbidden, `{"message":"You are not authorized!"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-folders"}, context)
+
+	expected := `Service returned status code '403': You are not authorized!
+Hint: Make sure your credentials have been granted the OAuth scopes for this operation: one of: OR.Folders, OR.Folders.Read`
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected service error with scopes hint %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorShowsScopesFromSecurityOnUnauthorized(t *testing.T) {
+	definition := `
+paths:
+  /digitization/start:
+    post:
+      operationId: start-digitization
+components:
+  securitySchemes:
+    oauth2:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://cloud.uipath.com/identity_/connect/token
+          scopes:
+            Du.Digitization.Api: Digitization Api Scope
+security:
+  - oauth2:
+      - Du.Digitization.Api
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusUnauthorized, `{"error":{"code":"Unauthorized","message":"The token is missing the required scope."}}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "start-digitization"}, context)
+
+	expected := `Service returned status code '401': The token is missing the required scope. (error code: Unauthorized)
+Hint: Make sure your credentials have been granted the OAuth scopes for this operation: Du.Digitization.Api`
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected service error with scopes hint %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorOperationSecurityOverridesDocumentSecurity(t *testing.T) {
+	definition := `
+paths:
+  /classification/classify:
+    post:
+      operationId: classify
+      security:
+        - oauth2:
+            - Du.Classification.Api
+security:
+  - oauth2:
+      - Du.Digitization.Api
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusForbidden, `{"error":{"code":"Forbidden","message":"Access denied."}}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "classify"}, context)
+
+	expected := `Service returned status code '403': Access denied. (error code: Forbidden)
+Hint: Make sure your credentials have been granted the OAuth scopes for this operation: Du.Classification.Api`
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected service error with scopes hint %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorDoesNotShowHintForOtherStatusCodes(t *testing.T) {
+	definition := `
+paths:
+  /odata/Folders:
+    get:
+      operationId: get-folders
+      description: 'OAuth required scopes: OR.Folders or OR.Folders.Read.'
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{"message":"Folder not found.","errorCode":1002}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-folders"}, context)
+
+	expected := "Service returned status code '404': Folder not found. (error code: 1002)"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected service error without hint %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorShowsIdentityValidationErrors(t *testing.T) {
+	definition := `
+paths:
+  /api/Group:
+    post:
+      operationId: create-group
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusBadRequest, `{"type":"https://tools.ietf.org/html/rfc7231#section-6.5.1","title":"One or more validation errors occurred.","status":400,"traceId":"00-9f8e7d6c-00","errors":{"Name":["The Name field is required."]}}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "create-group"}, context)
+
+	expected := "Service returned status code '400': One or more validation errors occurred. Name: The Name field is required. (trace id: 00-9f8e7d6c-00)"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf("Expected identity validation error %v, but got: %v", expected, result.Error)
+	}
+}
+
+func TestServiceErrorJsonFormatContainsHint(t *testing.T) {
+	definition := `
+paths:
+  /odata/Folders:
+    get:
+      operationId: get-folders
+      description: 'OAuth required scopes: OR.Folders or OR.Folders.Read.'
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusForbidden, `{"message":"You are not authorized!","errorCode":0}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "get-folders", "--error-format", "json"}, context)
+
+	stderr := ParseErrorOutput(t, result.StdErr)
+	expected := map[string]interface{}{
+		"category":  "authenticationError",
+		"exitCode":  3.0,
+		"message":   "Service returned status code '403': You are not authorized! (error code: 0)",
+		"hint":      "Hint: Make sure your credentials have been granted the OAuth scopes for this operation: one of: OR.Folders, OR.Folders.Read",
+		"status":    403.0,
+		"requestId": result.RequestHeader["x-request-id"],
+		"service": map[string]interface{}{
+			"errorCode": 0.0,
+			"message":   "You are not authorized!",
+		},
+	}
+	AssertErrorOutput(t, expected, stderr)
+}
@@ -69,12 +69,12 @@ func (b *ContextBuilder) WithStdIn(input bytes.Buffer) *ContextBuilder {
 }
 
 func (b *ContextBuilder) WithResponse(statusCode int, body string) *ContextBuilder {
-	b.context.Responses["*"] = ResponseData{statusCode, body}
+	b.context.Responses["*"] = ResponseData{Status: statusCode, Body: body}
 	return b
 }
 
 func (b *ContextBuilder) WithUrlResponse(url string, statusCode int, body string) *ContextBuilder {
-	b.context.Responses[url] = ResponseData{statusCode, body}
+	b.context.Responses[url] = ResponseData{Status: statusCode, Body: body}
 	return b
 }
 
@@ -84,7 +84,7 @@ func (b *ContextBuilder) WithResponseHandler(handler func(RequestData) ResponseD
 }
 
 func (b *ContextBuilder) WithIdentityResponse(statusCode int, body string) *ContextBuilder {
-	b.context.IdentityResponse = ResponseData{statusCode, body}
+	b.context.IdentityResponse = ResponseData{Status: statusCode, Body: body}
 	return b
 }
 
@@ -106,6 +106,7 @@ type RequestData struct {
 type ResponseData struct {
 	Status int
 	Body   string
+	Header map[string]string
 }
 
 type Context struct {
@@ -195,6 +196,9 @@ func RunCli(args []string, ctx Context) Result {
 					Header: requestHeader,
 					Body:   body,
 				})
+				for key, value := range response.Header {
+					w.Header().Set(key, value)
+				}
 				w.WriteHeader(response.Status)
 				_, _ = w.Write([]byte(response.Body))
 				return
@@ -143,6 +143,8 @@ paths:
 
 	expectedNames := []string{
 		"debug",
+		"trace",
+		"trace-file",
 		"profile",
 		"uri",
 		"organization",
@@ -150,10 +152,30 @@ paths:
 		"insecure",
 		"call-timeout",
 		"max-attempts",
+		"retry-initial-delay",
+		"retry-max-delay",
+		"retry-multiplier",
+		"retry-jitter",
+		"retry-non-idempotent",
+		"rate-limit",
+		"rate-limit-burst",
 		"output",
+		"output-file",
+		"error-format",
 		"query",
+		"columns",
+		"template",
+		"template-file",
 		"wait",
 		"wait-timeout",
+		"paginate",
+		"batch",
+		"batch-concurrency",
+		"dry-run",
+		"dry-run-auth",
+		"export",
+		"export-token",
+		"no-fail-on-http-error",
 		"file",
 		"identity-uri",
 		"service-version",
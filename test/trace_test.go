@@ -0,0 +1,175 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracePrintsRequestTimings(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--trace"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	expected := regexp.MustCompile(`^Trace: GET http://127\.0\.0\.1:\d+/ping 200 \(attempt 1\): dns \S+, connect \S+, tls \S+, send \S+, ttfb \S+, transfer \S+, total \S+\n$`)
+	if !expected.MatchString(result.StdErr) {
+		t.Errorf("Expected request timings on stderr, got: %v", result.StdErr)
+	}
+}
+
+func TestTracePrintsEveryRetry(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	callCount := 0
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponseHandler(func(request RequestData) ResponseData {
+			callCount++
+			if callCount == 2 {
+				return ResponseData{Status: http.StatusOK, Body: `{}`}
+			}
+			return ResponseData{Status: http.StatusServiceUnavailable, Body: ""}
+		}).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--trace", "--retry-initial-delay", "1ms"}, context)
+
+	lines := strings.Split(strings.TrimSpace(result.StdErr), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], " 503 (attempt 1)") || !strings.Contains(lines[1], " 200 (attempt 2)") {
+		t.Errorf("Expected trace for every attempt, got: %v", result.StdErr)
+	}
+}
+
+func TestTraceFileContainsOpenTelemetrySpans(t *testing.T) {
+	clientId := fmt.Sprintf("trace-client-id-%d", time.Now().UnixNano())
+	config := `
+profiles:
+  - name: default
+    auth:
+      clientId: ` + clientId + `
+      clientSecret: trace-client-secret
+`
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithConfig(config).
+		WithResponse(http.StatusOK, `{}`).
+		WithIdentityResponse(http.StatusOK, `{"access_token": "my-jwt-access-token", "expires_in": 3600, "token_type": "Bearer"}`).
+		Build()
+
+	path := filepath.Join(t.TempDir(), "trace.json")
+	result := RunCli([]string{"myservice", "ping", "--trace-file", path}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if result.StdErr != "" {
+		t.Errorf("Expected no trace output on stderr, got: %v", result.StdErr)
+	}
+	spans := readTraceSpans(t, path)
+	root := spans[0]
+	if root["parentSpanId"] != nil || len(root["traceId"].(string)) != 32 {
+		t.Errorf("Expected root span, got: %v", root)
+	}
+	methods := []string{}
+	for _, span := range spans {
+		if span["kind"] != 3.0 {
+			continue
+		}
+		if span["parentSpanId"] != root["spanId"] || span["traceId"] != root["traceId"] {
+			t.Errorf("Expected request span to be child of root span, got: %v", span)
+		}
+		methods = append(methods, span["name"].(string))
+	}
+	if strings.Join(methods, ",") != "POST,GET" {
+		t.Errorf("Expected token and service request spans, got: %v", methods)
+	}
+}
+
+func TestTraceFileContainsRequestAttributes(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+`
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusNotFound, `{}`).
+		Build()
+
+	path := filepath.Join(t.TempDir(), "trace.json")
+	RunCli([]string{"myservice", "ping", "--trace-file", path}, context)
+
+	spans := readTraceSpans(t, path)
+	request := spans[1]
+	attributes := map[string]interface{}{}
+	for _, attribute := range request["attributes"].([]interface{}) {
+		value := attribute.(map[string]interface{})["value"].(map[string]interface{})
+		for _, v := range value {
+			attributes[attribute.(map[string]interface{})["key"].(string)] = v
+		}
+	}
+	if attributes["http.request.method"] != "GET" || attributes["http.response.status_code"] != "404" || attributes["server.address"] != "127.0.0.1" {
+		t.Errorf("Expected request attributes, got: %v", attributes)
+	}
+	status := request["status"].(map[string]interface{})
+	if status["code"] != 2.0 {
+		t.Errorf("Expected error status, got: %v", status)
+	}
+	phases := []string{}
+	for _, span := range spans {
+		if span["parentSpanId"] == request["spanId"] {
+			phases = append(phases, span["name"].(string))
+		}
+	}
+	if strings.Join(phases, ",") != "connect,send,wait,transfer" {
+		t.Errorf("Expected request phase spans, got: %v", phases)
+	}
+}
+
+func readTraceSpans(t *testing.T, path string) []map[string]interface{} {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading trace file: %v", err)
+	}
+	var trace struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []map[string]interface{} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	err = json.Unmarshal(data, &trace)
+	if err != nil {
+		t.Fatalf("Error parsing trace file: %v", err)
+	}
+	return trace.ResourceSpans[0].ScopeSpans[0].Spans
+}
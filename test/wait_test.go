@@ -158,3 +158,27 @@ paths:
 		t.Errorf("Expected timeout error, but got: %v", result.Error)
 	}
 }
+
+func TestWaitSupportsCustomFunctions(t *testing.T) {
+	definition := `
+paths:
+  /ping:
+    get:
+      operationId: ping
+      summary: Simple ping
+`
+
+	context := NewContextBuilder().
+		WithDefinition("myservice", definition).
+		WithResponse(http.StatusOK, `{"State":"Successful","StartTime":"2024-01-25T12:00:00Z","EndTime":"2024-01-25T12:05:00Z"}`).
+		Build()
+
+	result := RunCli([]string{"myservice", "ping", "--wait", "regex_match(State, '^Succ') && date_diff(StartTime, EndTime, 'minutes') < `10`", "--query", "State"}, context)
+
+	if result.Error != nil {
+		t.Errorf("Unexpected error, got: %v", result.Error)
+	}
+	if result.StdOut != "\"Successful\"\n" {
+		t.Errorf("Expected response body, but got: %v", result.StdOut)
+	}
+}
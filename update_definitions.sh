@@ -121,6 +121,22 @@ function set_parameter_property()
   | bin/yq '.paths[] |= with(select(.head.parameters != null); (.head.parameters[] | select(.name == "'"$name"'"))."'"$property_name"'" = '"$property_value"')'
 }
 
+############################################################
+# Sets a property for the given operation
+#
+# Arguments:
+#   - The operation id
+#   - The property to set
+#   - The new property value
+############################################################
+function set_operation_property()
+{
+  local operation_id="$1"
+  local property_name="$2"
+  local property_value="$3"
+  bin/yq '(.paths[][] | select(.operationId == "'"$operation_id"'"))."'"$property_name"'" = '"$property_value"
+}
+
 if [ ! -f "bin/yq" ]; then
   echo "Installing yq..."
   install_yq
@@ -142,4 +158,5 @@ download_definition "https://cloud.uipath.com/$organization/$tenant/orchestrator
 | update_server_url "https://cloud.uipath.com/{organization}/{tenant}/orchestrator_" \
 | set_parameter_property "X-UIPATH-OrganizationUnitId" "x-uipathcli-name" "\"folder-id\"" \
 | set_parameter_property "X-UIPATH-OrganizationUnitId" "required" "true" \
+| set_operation_property "Buckets_ListFilesById" "x-uipathcli-pagination" '{"tokenParameter": "continuationToken", "tokenField": "continuationToken", "itemsField": "items"}' \
 | save_definition "orchestrator"
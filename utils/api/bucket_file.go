@@ -0,0 +1,13 @@
+package api
+
+import "time"
+
+type BucketFile struct {
+	FullPath     string
+	Size         int64
+	LastModified time.Time
+}
+
+func NewBucketFile(fullPath string, size int64, lastModified time.Time) *BucketFile {
+	return &BucketFile{fullPath, size, lastModified}
+}
@@ -44,7 +44,7 @@ func (c DuClient) StartDigitization(projectId string, file stream.Stream, conten
 		return "", fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusAccepted {
-		return "", fmt.Errorf("Digitizer returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return "", network.NewServiceHttpError("Digitizer", response.StatusCode, response.Status, response.Header, body)
 	}
 	var result digitizeResponse
 	err = json.Unmarshal(body, &result)
@@ -83,7 +83,7 @@ func (c DuClient) GetDigitizationResult(projectId string, documentId string) (st
 		return "", fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Digitizer returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return "", network.NewServiceHttpError("Digitizer", response.StatusCode, response.Status, response.Header, body)
 	}
 	var result digitizeResultResponse
 	err = json.Unmarshal(body, &result)
@@ -158,7 +158,11 @@ func (c DuClient) httpClientSettings() network.HttpClientSettings {
 		c.settings.OperationId,
 		c.settings.Header,
 		c.settings.Timeout,
-		c.settings.MaxAttempts,
+		c.settings.Retry,
+		c.settings.RetryNonIdempotent,
+		c.settings.RateLimit,
+		c.settings.Connection,
+		c.settings.Tracer,
 		c.settings.Insecure)
 }
 
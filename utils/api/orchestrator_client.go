@@ -49,7 +49,7 @@ func (c OrchestratorClient) GetFolderId(filter string) (int, error) {
 		return -1, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return -1, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return -1, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 
 	var result getFoldersResponseJson
@@ -96,7 +96,7 @@ func (c OrchestratorClient) Upload(file stream.Stream, feedId string, uploadBar
 		return ErrPackageAlreadyExists
 	}
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	return nil
 }
@@ -165,7 +165,7 @@ func (c OrchestratorClient) GetReleases(folderId int, processKey string) ([]Rele
 		return []Release{}, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return []Release{}, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return []Release{}, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 
 	var result getReleasesResponseJson
@@ -222,7 +222,7 @@ func (c OrchestratorClient) CreateRelease(folderId int, processKey string, proce
 		return -1, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusCreated {
-		return -1, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return -1, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 
 	var result createReleaseResponseJson
@@ -268,7 +268,7 @@ func (c OrchestratorClient) UpdateRelease(folderId int, releaseId int, processKe
 		return -1, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return -1, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return -1, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	return releaseId, nil
 }
@@ -308,7 +308,7 @@ func (c OrchestratorClient) CreateTestSet(folderId int, releaseId int, processVe
 		return -1, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusCreated {
-		return -1, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return -1, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	return strconv.Atoi(string(body))
 }
@@ -344,7 +344,7 @@ func (c OrchestratorClient) ExecuteTestSet(folderId int, testSetId int) (int, er
 		return -1, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return -1, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return -1, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	return strconv.Atoi(string(body))
 }
@@ -392,7 +392,7 @@ func (c OrchestratorClient) GetTestSet(folderId int, testSetId int) (*TestSet, e
 		return nil, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return nil, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 
 	var result getTestSetResponseJson
@@ -429,7 +429,7 @@ func (c OrchestratorClient) GetTestExecution(folderId int, executionId int) (*Te
 		return nil, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return nil, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 
 	var result getTestExecutionResponseJson
@@ -465,7 +465,7 @@ func (c OrchestratorClient) GetRobotLogs(folderId int, jobKey string) ([]RobotLo
 		return []RobotLog{}, fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return []RobotLog{}, fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return []RobotLog{}, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 
 	var result getRobotLogsResponseJson
@@ -499,7 +499,7 @@ func (c OrchestratorClient) GetFolderFeed(folderId int) (string, error) {
 		return "", fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return "", network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	feedId := string(body)
 	if feedId == "null" {
@@ -531,7 +531,7 @@ func (c OrchestratorClient) GetReadUrl(folderId int, bucketId int, path string)
 		return "", fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return "", network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	var result urlResponse
 	err = json.Unmarshal(body, &result)
@@ -565,7 +565,7 @@ func (c OrchestratorClient) GetWriteUrl(folderId int, bucketId int, path string)
 		return "", fmt.Errorf("Error reading response: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Orchestrator returned status code '%v' and body '%v'", response.StatusCode, string(body))
+		return "", network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
 	}
 	var result urlResponse
 	err = json.Unmarshal(body, &result)
@@ -586,6 +586,98 @@ func (c OrchestratorClient) createWriteUrlRequest(folderId int, bucketId int, pa
 	return network.NewHttpGetRequest(uri, c.toAuthorization(c.token), header)
 }
 
+func (c OrchestratorClient) ListBucketFiles(folderId int, bucketId int, prefix string) ([]BucketFile, error) {
+	files := []BucketFile{}
+	continuationToken := ""
+	for {
+		result, err := c.listBucketFiles(folderId, bucketId, prefix, continuationToken)
+		if err != nil {
+			return []BucketFile{}, err
+		}
+		files = append(files, c.convertToBucketFiles(*result)...)
+		continuationToken = result.ContinuationToken
+		if continuationToken == "" {
+			return files, nil
+		}
+	}
+}
+
+func (c OrchestratorClient) listBucketFiles(folderId int, bucketId int, prefix string, continuationToken string) (*listBucketFilesResponseJson, error) {
+	request := c.createListBucketFilesRequest(folderId, bucketId, prefix, continuationToken)
+	client := network.NewHttpClient(c.logger, c.httpClientSettings())
+	response, err := client.Send(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
+	}
+
+	var result listBucketFilesResponseJson
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Orchestrator returned invalid response body '%v'", string(body))
+	}
+	return &result, nil
+}
+
+func (c OrchestratorClient) createListBucketFilesRequest(folderId int, bucketId int, prefix string, continuationToken string) *network.HttpRequest {
+	builder := c.newUriBuilder("/api/Buckets/{bucketId}/ListFiles").
+		FormatPath("bucketId", bucketId)
+	if prefix != "" {
+		builder.AddQueryString("prefix", prefix)
+	}
+	if continuationToken != "" {
+		builder.AddQueryString("continuationToken", continuationToken)
+	}
+	header := http.Header{
+		"X-UiPath-OrganizationUnitId": {strconv.Itoa(folderId)},
+	}
+	return network.NewHttpGetRequest(builder.Build(), c.toAuthorization(c.token), header)
+}
+
+func (c OrchestratorClient) convertToBucketFiles(json listBucketFilesResponseJson) []BucketFile {
+	files := []BucketFile{}
+	for _, item := range json.Items {
+		files = append(files, *NewBucketFile(item.FullPath, item.Size, item.LastModified))
+	}
+	return files
+}
+
+func (c OrchestratorClient) DeleteBucketFile(folderId int, bucketId int, path string) error {
+	request := c.createDeleteBucketFileRequest(folderId, bucketId, path)
+	client := network.NewHttpClient(c.logger, c.httpClientSettings())
+	response, err := client.Send(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("Error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return network.NewServiceHttpError("Orchestrator", response.StatusCode, response.Status, response.Header, body)
+	}
+	return nil
+}
+
+func (c OrchestratorClient) createDeleteBucketFileRequest(folderId int, bucketId int, path string) *network.HttpRequest {
+	uri := c.newUriBuilder("/odata/Buckets({bucketId})/UiPath.Server.Configuration.OData.DeleteFile").
+		FormatPath("bucketId", bucketId).
+		AddQueryString("path", path).
+		Build()
+	header := http.Header{
+		"X-UiPath-OrganizationUnitId": {strconv.Itoa(folderId)},
+	}
+	return network.NewHttpRequest(http.MethodDelete, uri, c.toAuthorization(c.token), header, http.NoBody, 0)
+}
+
 func (c OrchestratorClient) convertToRobotLogs(json getRobotLogsResponseJson) []RobotLog {
 	logs := []RobotLog{}
 	for _, l := range json.Value {
@@ -696,7 +788,11 @@ func (c OrchestratorClient) httpClientSettings() network.HttpClientSettings {
 		c.settings.OperationId,
 		c.settings.Header,
 		c.settings.Timeout,
-		c.settings.MaxAttempts,
+		c.settings.Retry,
+		c.settings.RetryNonIdempotent,
+		c.settings.RateLimit,
+		c.settings.Connection,
+		c.settings.Tracer,
 		c.settings.Insecure)
 }
 
@@ -843,6 +939,17 @@ type getRobotLogJson struct {
 	RuntimeType     string    `json:"RuntimeType"`
 }
 
+type listBucketFilesResponseJson struct {
+	Items             []listBucketFilesItemJson `json:"items"`
+	ContinuationToken string                    `json:"continuationToken"`
+}
+
+type listBucketFilesItemJson struct {
+	FullPath     string    `json:"fullPath"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
+
 type urlResponse struct {
 	Uri string `json:"Uri"`
 }
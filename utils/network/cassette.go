@@ -0,0 +1,31 @@
+package network
+
+import (
+	"net/http"
+)
+
+// cassette contains the recorded responses for a single request.
+//
+// Requests are matched by method, URL and the hash of the normalized body.
+// Multiple responses are replayed in the order they have been recorded.
+type cassette struct {
+	Method    string             `json:"method"`
+	Url       string             `json:"url"`
+	BodyHash  string             `json:"bodyHash"`
+	Responses []cassetteResponse `json:"responses"`
+}
+
+// cassetteResponse is a recorded response. Text bodies are stored as is,
+// binary bodies are base64 encoded.
+type cassetteResponse struct {
+	Status     string      `json:"status"`
+	StatusCode int         `json:"statusCode"`
+	Proto      string      `json:"proto"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"bodyBase64,omitempty"`
+}
+
+func newCassette(method string, url string, bodyHash string) *cassette {
+	return &cassette{method, url, bodyHash, []cassetteResponse{}}
+}
@@ -0,0 +1,227 @@
+package network
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const RecordDirectoryVarName = "UIPATH_RECORD"
+const ReplayDirectoryVarName = "UIPATH_REPLAY"
+
+const cassetteRedactedValue = "**redacted**"
+
+// The credentials in these response headers and JSON body fields are not
+// stored in the cassette files because they are usually committed as fixtures.
+var cassetteRedactedHeaders = []string{"Authorization", "Set-Cookie"}
+var cassetteRedactedFields = []string{"access_token", "refresh_token", "id_token"}
+
+var cassetteLock sync.Mutex
+var cassettePositions = map[string]int{}
+
+// cassetteStore records HTTP responses in cassette files or replays them
+// instead of sending the request.
+//
+// Record mode is enabled by setting UIPATH_RECORD to a directory,
+// replay mode by setting UIPATH_REPLAY.
+type cassetteStore struct {
+	directory string
+	replay    bool
+}
+
+func (s cassetteStore) normalizeUrl(requestUrl string) string {
+	uri, err := url.Parse(requestUrl)
+	if err != nil {
+		return requestUrl
+	}
+	uri.RawQuery = uri.Query().Encode()
+	return uri.String()
+}
+
+func (s cassetteStore) normalizeBody(request *HttpRequest, body []byte) []byte {
+	mediaType, params, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json":
+		var data interface{}
+		err := json.Unmarshal(body, &data)
+		if err != nil {
+			return body
+		}
+		result, err := json.Marshal(data)
+		if err != nil {
+			return body
+		}
+		return result
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		return []byte(values.Encode())
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		return bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("boundary"))
+	}
+	return body
+}
+
+func (s cassetteStore) bodyHash(request *HttpRequest, body []byte) string {
+	hash := sha256.Sum256(s.normalizeBody(request, body))
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+func (s cassetteStore) path(method string, url string, bodyHash string) string {
+	hash := sha256.Sum256([]byte(method + " " + url + " " + bodyHash))
+	fileName := strings.ToLower(method) + "-" + hex.EncodeToString(hash[:8]) + ".json"
+	return filepath.Join(s.directory, fileName)
+}
+
+func (s cassetteStore) read(path string) (*cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result cassette
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cassette file '%s': %w", path, err)
+	}
+	return &result, nil
+}
+
+func (s cassetteStore) write(path string, cassette cassette) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(s.directory, 0700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (s cassetteStore) redactHeader(header http.Header) http.Header {
+	result := header.Clone()
+	for _, name := range cassetteRedactedHeaders {
+		if result.Get(name) != "" {
+			result.Set(name, cassetteRedactedValue)
+		}
+	}
+	return result
+}
+
+func (s cassetteStore) redactBody(body []byte) []byte {
+	var data map[string]interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return body
+	}
+	redacted := false
+	for _, field := range cassetteRedactedFields {
+		if _, found := data[field]; found {
+			data[field] = cassetteRedactedValue
+			redacted = true
+		}
+	}
+	if !redacted {
+		return body
+	}
+	result, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return result
+}
+
+func (s cassetteStore) Record(request *HttpRequest, requestBody []byte, response *HttpResponse) (*HttpResponse, error) {
+	defer func() { _ = response.Body.Close() }()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %w", err)
+	}
+
+	url := s.normalizeUrl(request.URL)
+	bodyHash := s.bodyHash(request, requestBody)
+	path := s.path(request.Method, url, bodyHash)
+
+	recordedResponse := cassetteResponse{
+		Status:     response.Status,
+		StatusCode: response.StatusCode,
+		Proto:      response.Proto,
+		Header:     s.redactHeader(response.Header),
+	}
+	if utf8.Valid(body) {
+		recordedResponse.Body = string(s.redactBody(body))
+	} else {
+		recordedResponse.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	cassetteLock.Lock()
+	defer cassetteLock.Unlock()
+	result, err := s.read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		result, err = newCassette(request.Method, url, bodyHash), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error recording response: %w", err)
+	}
+	result.Responses = append(result.Responses, recordedResponse)
+	err = s.write(path, *result)
+	if err != nil {
+		return nil, fmt.Errorf("Error recording response: %w", err)
+	}
+	return NewHttpResponse(response.Status, response.StatusCode, response.Proto, response.Header, io.NopCloser(bytes.NewReader(body)), int64(len(body))), nil
+}
+
+func (s cassetteStore) Replay(request *HttpRequest, requestBody []byte) (*HttpResponse, error) {
+	url := s.normalizeUrl(request.URL)
+	bodyHash := s.bodyHash(request, requestBody)
+	path := s.path(request.Method, url, bodyHash)
+
+	cassetteLock.Lock()
+	defer cassetteLock.Unlock()
+	result, err := s.read(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(result.Responses) == 0) {
+		return nil, fmt.Errorf("No recorded response found in '%s' for request %s %s with body %s", s.directory, request.Method, url, bodyHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error replaying response: %w", err)
+	}
+	position := min(cassettePositions[path], len(result.Responses)-1)
+	cassettePositions[path] = position + 1
+
+	response := result.Responses[position]
+	body := []byte(response.Body)
+	if response.BodyBase64 != "" {
+		body, err = base64.StdEncoding.DecodeString(response.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid cassette file '%s': %w", path, err)
+		}
+	}
+	return NewHttpResponse(response.Status, response.StatusCode, response.Proto, response.Header, io.NopCloser(bytes.NewReader(body)), int64(len(body))), nil
+}
+
+func newCassetteStoreFromEnv() *cassetteStore {
+	replayDirectory := os.Getenv(ReplayDirectoryVarName)
+	if replayDirectory != "" {
+		return &cassetteStore{replayDirectory, true}
+	}
+	recordDirectory := os.Getenv(RecordDirectoryVarName)
+	if recordDirectory != "" {
+		return &cassetteStore{recordDirectory, false}
+	}
+	return nil
+}
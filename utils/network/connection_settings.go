@@ -0,0 +1,36 @@
+package network
+
+// The ConnectionSettings contain the proxy and TLS settings for connecting to
+// the services.
+//
+// Proxy overrides the proxy from the HTTP_PROXY and HTTPS_PROXY environment
+// variables and NoProxy is a comma-separated list of hosts which are
+// connected directly. CaCertFile adds the certificates from the PEM file to
+// the trusted root certificates and ClientCertFile/ClientKeyFile provide the
+// client certificate for mutual TLS. Transport limits the idle connections
+// which are kept open for reuse.
+type ConnectionSettings struct {
+	Proxy          string
+	NoProxy        string
+	CaCertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
+	Transport      TransportSettings
+}
+
+func NewConnectionSettings(
+	proxy string,
+	noProxy string,
+	caCertFile string,
+	clientCertFile string,
+	clientKeyFile string,
+	transport TransportSettings) *ConnectionSettings {
+	return &ConnectionSettings{
+		proxy,
+		noProxy,
+		caCertFile,
+		clientCertFile,
+		clientKeyFile,
+		transport,
+	}
+}
@@ -6,11 +6,13 @@ package network
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
 	"runtime"
+	"time"
 
 	"github.com/UiPath/uipathcli/log"
 	"github.com/UiPath/uipathcli/utils"
@@ -18,8 +20,9 @@ import (
 )
 
 type HttpClient struct {
-	logger   log.Logger
-	settings HttpClientSettings
+	logger    log.Logger
+	settings  HttpClientSettings
+	cassettes *cassetteStore
 }
 
 const bufferLimit = 10 * 1024 * 1024
@@ -35,27 +38,106 @@ func (c HttpClient) SendWithContext(request *HttpRequest, ctx context.Context) (
 	return c.sendWithRetries(request, ctx)
 }
 
-func (c HttpClient) sendWithRetries(request *HttpRequest, ctx context.Context) (*HttpResponse, error) {
-	request.Header.Set("User-Agent", UserAgent)
-	request.Header.Set("x-request-id", c.settings.OperationId)
+// Header returns the headers which are sent for the given request including
+// the common headers added by the client.
+func (c HttpClient) Header(request *HttpRequest) http.Header {
+	header := http.Header{}
+	if request.Header != nil {
+		header = request.Header.Clone()
+	}
+	header.Set("User-Agent", UserAgent)
+	header.Set("x-request-id", c.settings.OperationId)
 	if request.Authorization != nil {
-		request.Header.Set("Authorization", fmt.Sprintf("%s %s", request.Authorization.Type, request.Authorization.Value))
+		header.Set("Authorization", fmt.Sprintf("%s %s", request.Authorization.Type, request.Authorization.Value))
+	}
+	for k, v := range c.settings.Header {
+		header.Set(k, v)
+	}
+	return header
+}
+
+func (c HttpClient) sendWithRetries(request *HttpRequest, ctx context.Context) (*HttpResponse, error) {
+	request.Header = c.Header(request)
+	if c.cassettes != nil {
+		return c.sendWithCassettes(request, ctx)
+	}
+	return c.retry(request, ctx)
+}
+
+func (c HttpClient) sendWithCassettes(request *HttpRequest, ctx context.Context) (*HttpResponse, error) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading request body: %w", err)
+	}
+	if cause := context.Cause(ctx); cause != nil {
+		return nil, fmt.Errorf("Error sending request: %w", cause)
+	}
+	request.Body = bytes.NewReader(body)
+
+	if !c.cassettes.replay {
+		response, err := c.retry(request, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return c.cassettes.Record(request, body, response)
+	}
+
+	if c.settings.Debug {
+		c.logRequest(request, body)
+	}
+	response, err := c.cassettes.Replay(request, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.settings.Debug {
+		response.Body = newResettableReader(response.Body, bufferLimit, func(body []byte) { c.logResponse(response, body) })
+	}
+	return response, nil
+}
+
+func (c HttpClient) retryPolicy(request *HttpRequest) resiliency.RetryPolicy {
+	policy := c.settings.Retry
+	if !c.settings.RetryNonIdempotent && !c.isIdempotent(request.Method) {
+		policy.MaxAttempts = 1
+	}
+	return policy
+}
+
+func (c HttpClient) isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func (c HttpClient) isRetryableStatusCode(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500
+}
+
+func (c HttpClient) retry(request *HttpRequest, ctx context.Context) (*HttpResponse, error) {
+	policy := c.retryPolicy(request)
+	transport, err := sharedTransportPool.Get(c.settings)
+	if err != nil {
+		return nil, err
 	}
 
 	if c.settings.Debug {
 		request.Body = newResettableReader(request.Body, bufferLimit, func(body []byte) { c.logRequest(request, body) })
-	} else if c.settings.MaxAttempts > 1 {
+	} else if policy.MaxAttempts > 1 {
 		request.Body = newResettableReader(request.Body, bufferLimit, func(body []byte) {})
 	}
 
 	var response *HttpResponse
-	var err error
-	err = resiliency.RetryN(c.settings.MaxAttempts, func(attempt int) error {
+	err = resiliency.RetryWithContext(policy, ctx, func(attempt int) error {
 		if attempt > 1 && !c.resetReader(request.Body) {
 			return err
 		}
 
-		response, err = c.send(request, ctx)
+		response, err = c.send(request, transport, attempt, ctx)
 		if err != nil {
 			return resiliency.Retryable(err)
 		}
@@ -64,13 +146,14 @@ func (c HttpClient) sendWithRetries(request *HttpRequest, ctx context.Context) (
 			response.Body = newResettableReader(response.Body, bufferLimit, func(body []byte) { c.logResponse(response, body) })
 		}
 
-		if response.StatusCode == 0 || response.StatusCode >= 500 {
+		if c.isRetryableStatusCode(response.StatusCode) {
 			defer func() { _ = response.Body.Close() }()
 			body, err := io.ReadAll(response.Body)
 			if err != nil {
 				return resiliency.Retryable(fmt.Errorf("Error reading response: %w", err))
 			}
-			return resiliency.Retryable(fmt.Errorf("Service returned status code '%v' and body '%v'", response.StatusCode, string(body)))
+			retryAfter := parseRetryAfter(response.Header, time.Now(), policy.MaxDelay)
+			return resiliency.RetryableAfter(NewHttpError(response.StatusCode, response.Status, response.Header, body), retryAfter)
 		}
 		return nil
 	})
@@ -85,14 +168,33 @@ func (c HttpClient) resetReader(reader io.Reader) bool {
 	return false
 }
 
-func (c HttpClient) send(request *HttpRequest, ctx context.Context) (*HttpResponse, error) {
-	transport := &http.Transport{
-		Proxy:                 http.ProxyFromEnvironment,
-		TLSClientConfig:       &tls.Config{InsecureSkipVerify: c.settings.Insecure}, //nolint:gosec // This is user configurable and disabled by default
-		ResponseHeaderTimeout: c.settings.Timeout,
+func (c HttpClient) waitForRateLimit(request *HttpRequest, ctx context.Context) error {
+	uri, err := url.Parse(request.URL)
+	if err != nil {
+		return nil
+	}
+	limit := c.settings.RateLimit.Get(uri.Host, uri.Hostname())
+	limiter := sharedRateLimiter(uri.Host, limit)
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+func (c HttpClient) send(request *HttpRequest, transport *http.Transport, attempt int, ctx context.Context) (*HttpResponse, error) {
+	err := c.waitForRateLimit(request, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error sending request: %w", err)
 	}
+
 	client := &http.Client{Transport: transport}
 
+	var trace *requestTrace
+	if c.settings.Tracer != nil {
+		trace = newRequestTrace(c.settings.Tracer, request, attempt)
+		ctx = httptrace.WithClientTrace(ctx, trace.ClientTrace())
+	}
+
 	responseChan := make(chan *HttpResponse)
 	ctx, cancel := context.WithCancelCause(ctx)
 	go func(client *http.Client, request *HttpRequest) {
@@ -102,14 +204,11 @@ func (c HttpClient) send(request *HttpRequest, ctx context.Context) (*HttpRespon
 			return
 		}
 		req.Header = request.Header
-		for k, v := range c.settings.Header {
-			req.Header.Set(k, v)
-		}
 		req.ContentLength = request.ContentLength
 
 		resp, err := client.Do(req) //nolint:bodyclose // The response body needs to be closed by the caller to support streaming
 		if err != nil {
-			cancel(fmt.Errorf("Error sending request: %w", err))
+			cancel(NewNetworkError(fmt.Errorf("Error sending request: %w", err)))
 			return
 		}
 
@@ -125,12 +224,27 @@ func (c HttpClient) send(request *HttpRequest, ctx context.Context) (*HttpRespon
 
 	select {
 	case <-ctx.Done():
-		return nil, fmt.Errorf("Error sending request: %w", context.Cause(ctx))
+		err := fmt.Errorf("Error sending request: %w", context.Cause(ctx))
+		c.finishTrace(trace, 0, err)
+		return nil, err
 	case response := <-responseChan:
+		if trace != nil {
+			response.Body = newTracedReader(response.Body, func() { c.finishTrace(trace, response.StatusCode, nil) })
+		}
 		return response, nil
 	}
 }
 
+func (c HttpClient) finishTrace(trace *requestTrace, statusCode int, err error) {
+	if trace == nil {
+		return
+	}
+	trace.Finish(statusCode, err)
+	if trace.tracer.Print() {
+		c.logger.LogError("Trace: " + trace.Summary() + "\n")
+	}
+}
+
 func (c HttpClient) logRequest(request *HttpRequest, body []byte) {
 	reader := bytes.NewReader(c.truncate(body, loggingLimit))
 	requestInfo := log.NewRequestInfo(request.Method, request.URL, request.Proto, request.Header, reader)
@@ -151,5 +265,5 @@ func (c HttpClient) truncate(data []byte, size int) []byte {
 }
 
 func NewHttpClient(logger log.Logger, settings HttpClientSettings) *HttpClient {
-	return &HttpClient{logger, settings}
+	return &HttpClient{logger, settings, newCassetteStoreFromEnv()}
 }
@@ -2,15 +2,21 @@ package network
 
 import (
 	"time"
+
+	"github.com/UiPath/uipathcli/utils/resiliency"
 )
 
 type HttpClientSettings struct {
-	Debug       bool
-	OperationId string
-	Header      map[string]string
-	Timeout     time.Duration
-	MaxAttempts int
-	Insecure    bool
+	Debug              bool
+	OperationId        string
+	Header             map[string]string
+	Timeout            time.Duration
+	Retry              resiliency.RetryPolicy
+	RetryNonIdempotent bool
+	RateLimit          RateLimitSettings
+	Connection         ConnectionSettings
+	Tracer             *Tracer
+	Insecure           bool
 }
 
 func NewHttpClientSettings(
@@ -18,14 +24,22 @@ func NewHttpClientSettings(
 	operationId string,
 	header map[string]string,
 	timeout time.Duration,
-	maxAttempts int,
+	retry resiliency.RetryPolicy,
+	retryNonIdempotent bool,
+	rateLimit RateLimitSettings,
+	connection ConnectionSettings,
+	tracer *Tracer,
 	insecure bool) *HttpClientSettings {
 	return &HttpClientSettings{
 		debug,
 		operationId,
 		header,
 		timeout,
-		maxAttempts,
+		retry,
+		retryNonIdempotent,
+		rateLimit,
+		connection,
+		tracer,
 		insecure,
 	}
 }
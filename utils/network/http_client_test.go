@@ -0,0 +1,117 @@
+package network
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/UiPath/uipathcli/log"
+	"github.com/UiPath/uipathcli/utils/resiliency"
+)
+
+func TestHttpClientReusesConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := newTestServer(&connections)
+	defer server.Close()
+
+	for i := 0; i < 5; i++ {
+		client := newTestClient()
+		sendTestRequest(t, client, server.URL)
+	}
+
+	if connections.Load() != 1 {
+		t.Errorf("Expected requests to reuse a single connection, got: %v connections", connections.Load())
+	}
+}
+
+func TestHttpClientUsesSeparateTransportForDifferentSettings(t *testing.T) {
+	pool := newTransportPool()
+
+	first, _ := pool.Get(*NewHttpClientSettings(false, "", nil, 10*time.Second, *resiliency.NewDefaultRetryPolicy(1), false, RateLimitSettings{}, ConnectionSettings{}, nil, true))
+	second, _ := pool.Get(*NewHttpClientSettings(true, "op", nil, 10*time.Second, *resiliency.NewDefaultRetryPolicy(3), false, RateLimitSettings{}, ConnectionSettings{}, nil, true))
+	other, _ := pool.Get(*NewHttpClientSettings(false, "", nil, 10*time.Second, *resiliency.NewDefaultRetryPolicy(1), false, RateLimitSettings{}, ConnectionSettings{}, nil, false))
+
+	if first != second {
+		t.Errorf("Expected same transport for same connection settings")
+	}
+	if first == other {
+		t.Errorf("Expected different transport for different connection settings")
+	}
+}
+
+func TestHttpClientUsesTransportSettingsFromConnection(t *testing.T) {
+	pool := newTransportPool()
+
+	connection := *NewConnectionSettings("", "", "", "", "", *NewTransportSettings(5, 2, 30*time.Second))
+	configured, _ := pool.Get(*NewHttpClientSettings(false, "", nil, 10*time.Second, *resiliency.NewDefaultRetryPolicy(1), false, RateLimitSettings{}, connection, nil, false))
+	defaults, _ := pool.Get(*NewHttpClientSettings(false, "", nil, 10*time.Second, *resiliency.NewDefaultRetryPolicy(1), false, RateLimitSettings{}, ConnectionSettings{}, nil, false))
+
+	if configured.MaxIdleConns != 5 || configured.MaxIdleConnsPerHost != 2 || configured.IdleConnTimeout != 30*time.Second {
+		t.Errorf("Expected configured idle connection limits, got: %v, %v, %v", configured.MaxIdleConns, configured.MaxIdleConnsPerHost, configured.IdleConnTimeout)
+	}
+	if defaults.MaxIdleConns != DefaultMaxIdleConns || defaults.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || defaults.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("Expected default idle connection limits, got: %v, %v, %v", defaults.MaxIdleConns, defaults.MaxIdleConnsPerHost, defaults.IdleConnTimeout)
+	}
+}
+
+func BenchmarkHttpClientSharedTransport(b *testing.B) {
+	server := newTestServer(&atomic.Int32{})
+	defer server.Close()
+
+	for b.Loop() {
+		client := newTestClient()
+		sendTestRequest(b, client, server.URL)
+	}
+}
+
+func BenchmarkHttpClientNewTransportPerRequest(b *testing.B) {
+	server := newTestServer(&atomic.Int32{})
+	defer server.Close()
+
+	for b.Loop() {
+		transport := &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // Test server uses self-signed certificate
+		}
+		client := &http.Client{Transport: transport}
+		response, err := client.Get(server.URL)
+		if err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+		transport.CloseIdleConnections()
+	}
+}
+
+func newTestServer(connections *atomic.Int32) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.StartTLS()
+	return server
+}
+
+func newTestClient() *HttpClient {
+	settings := NewHttpClientSettings(false, "", map[string]string{}, 10*time.Second, *resiliency.NewDefaultRetryPolicy(1), false, RateLimitSettings{}, ConnectionSettings{}, nil, true)
+	return NewHttpClient(log.NewDefaultLogger(io.Discard), *settings)
+}
+
+func sendTestRequest(tb testing.TB, client *HttpClient, url string) {
+	request := NewHttpRequest(http.MethodGet, url, nil, http.Header{}, http.NoBody, 0)
+	response, err := client.Send(request)
+	if err != nil {
+		tb.Fatalf("Unexpected error: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+}
@@ -0,0 +1,39 @@
+package network
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const defaultServiceName = "Service"
+
+// The HttpError is returned when the service responds with an unsuccessful
+// status code.
+type HttpError struct {
+	Service    string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+// ServiceName returns the name of the service which returned the error, e.g.
+// Orchestrator.
+func (e HttpError) ServiceName() string {
+	if e.Service == "" {
+		return defaultServiceName
+	}
+	return e.Service
+}
+
+func (e HttpError) Error() string {
+	return fmt.Sprintf("%s returned status code '%v' and body '%v'", e.ServiceName(), e.StatusCode, string(e.Body))
+}
+
+func NewHttpError(statusCode int, status string, header http.Header, body []byte) *HttpError {
+	return &HttpError{"", statusCode, status, header, body}
+}
+
+func NewServiceHttpError(service string, statusCode int, status string, header http.Header, body []byte) *HttpError {
+	return &HttpError{service, statusCode, status, header, body}
+}
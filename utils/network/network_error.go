@@ -0,0 +1,19 @@
+package network
+
+// The NetworkError is returned when the request could not be sent or no
+// response has been received from the service.
+type NetworkError struct {
+	err error
+}
+
+func (e NetworkError) Error() string {
+	return e.err.Error()
+}
+
+func (e NetworkError) Unwrap() error {
+	return e.err
+}
+
+func NewNetworkError(err error) *NetworkError {
+	return &NetworkError{err}
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// The otlp structures represent the OpenTelemetry protocol JSON encoding
+// of trace data.
+type otlpTraceData struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code int `json:"code"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+func newOtlpAttribute(key string, value interface{}) otlpAttribute {
+	result := otlpValue{}
+	switch v := value.(type) {
+	case int:
+		intValue := strconv.Itoa(v)
+		result.IntValue = &intValue
+	case int64:
+		intValue := strconv.FormatInt(v, 10)
+		result.IntValue = &intValue
+	case bool:
+		result.BoolValue = &v
+	default:
+		stringValue := fmt.Sprint(v)
+		result.StringValue = &stringValue
+	}
+	return otlpAttribute{key, result}
+}
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// newProxyFunc returns the function selecting the proxy for a request based
+// on the connection settings. The proxy from the environment is used in case
+// no proxy is configured.
+func newProxyFunc(settings ConnectionSettings) (func(*http.Request) (*url.URL, error), error) {
+	if settings.Proxy == "" && settings.NoProxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	var proxyUrl *url.URL
+	if settings.Proxy != "" {
+		var err error
+		proxyUrl, err = url.Parse(settings.Proxy)
+		if err != nil || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("Invalid proxy '%s'", settings.Proxy)
+		}
+	}
+	noProxy := strings.Split(settings.NoProxy, ",")
+	return func(request *http.Request) (*url.URL, error) {
+		if matchesNoProxy(noProxy, request.URL) {
+			return nil, nil
+		}
+		if proxyUrl != nil {
+			return proxyUrl, nil
+		}
+		return http.ProxyFromEnvironment(request)
+	}, nil
+}
+
+// matchesNoProxy checks if the host of the url is excluded from the proxy.
+// The entries can be a host name which also matches its subdomains, a domain
+// starting with a dot, an IP address, a CIDR range or * to match all hosts.
+// Entries including a port only match requests to that port.
+func matchesNoProxy(noProxy []string, uri *url.URL) bool {
+	hostname := strings.ToLower(uri.Hostname())
+	port := uri.Port()
+	if port == "" && uri.Scheme == "https" {
+		port = "443"
+	} else if port == "" {
+		port = "80"
+	}
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			ip := net.ParseIP(hostname)
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+		if err != nil {
+			entryHost = entry
+			entryPort = ""
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+		entryHost = strings.TrimPrefix(entryHost, "*")
+		if strings.HasPrefix(entryHost, ".") {
+			if strings.HasSuffix(hostname, entryHost) || hostname == entryHost[1:] {
+				return true
+			}
+			continue
+		}
+		if hostname == entryHost || strings.HasSuffix(hostname, "."+entryHost) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNoProxyMatchesHosts(t *testing.T) {
+	t.Run("ExactHost", func(t *testing.T) { NoProxyMatches(t, "cloud.uipath.com", "https://cloud.uipath.com/org", true) })
+	t.Run("Subdomain", func(t *testing.T) { NoProxyMatches(t, "uipath.com", "https://cloud.uipath.com/org", true) })
+	t.Run("LeadingDot", func(t *testing.T) { NoProxyMatches(t, ".uipath.com", "https://cloud.uipath.com/org", true) })
+	t.Run("Wildcard", func(t *testing.T) { NoProxyMatches(t, "*", "https://cloud.uipath.com/org", true) })
+	t.Run("CIDR", func(t *testing.T) { NoProxyMatches(t, "10.0.0.0/8", "https://10.1.2.3/org", true) })
+	t.Run("MatchingPort", func(t *testing.T) { NoProxyMatches(t, "cloud.uipath.com:443", "https://cloud.uipath.com/org", true) })
+	t.Run("DifferentPort", func(t *testing.T) { NoProxyMatches(t, "cloud.uipath.com:8443", "https://cloud.uipath.com/org", false) })
+	t.Run("OtherHost", func(t *testing.T) { NoProxyMatches(t, "localhost,.corp.local", "https://cloud.uipath.com/org", false) })
+	t.Run("PartialName", func(t *testing.T) { NoProxyMatches(t, "path.com", "https://cloud.uipath.com/org", false) })
+}
+
+func NoProxyMatches(t *testing.T, noProxy string, requestUrl string, expected bool) {
+	uri, _ := url.Parse(requestUrl)
+
+	result := matchesNoProxy(strings.Split(noProxy, ","), uri)
+
+	if result != expected {
+		t.Errorf("Expected no proxy '%s' match for %s to be %v, got: %v", noProxy, requestUrl, expected, result)
+	}
+}
+
+func TestProxyFuncReturnsConfiguredProxy(t *testing.T) {
+	proxy, err := newProxyFunc(*NewConnectionSettings("http://proxy.corp.local:8080", "localhost", "", "", "", TransportSettings{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://cloud.uipath.com", nil)
+	proxyUrl, _ := proxy(request)
+	if proxyUrl == nil || proxyUrl.String() != "http://proxy.corp.local:8080" {
+		t.Errorf("Expected configured proxy, got: %v", proxyUrl)
+	}
+
+	request, _ = http.NewRequest(http.MethodGet, "http://localhost:8080", nil)
+	proxyUrl, _ = proxy(request)
+	if proxyUrl != nil {
+		t.Errorf("Expected no proxy for excluded host, got: %v", proxyUrl)
+	}
+}
+
+func TestProxyFuncInvalidProxyReturnsError(t *testing.T) {
+	_, err := newProxyFunc(*NewConnectionSettings("invalid", "", "", "", "", TransportSettings{}))
+
+	if err == nil || err.Error() != "Invalid proxy 'invalid'" {
+		t.Errorf("Expected invalid proxy error, got: %v", err)
+	}
+}
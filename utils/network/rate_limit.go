@@ -0,0 +1,36 @@
+package network
+
+// The RateLimit defines how many requests per second can be sent to a
+// service host. Burst is the number of requests which can be sent at once
+// before the rate applies. A zero rate disables rate limiting.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// The RateLimitSettings contains the default rate limit for all service hosts
+// and the overrides for specific hosts.
+type RateLimitSettings struct {
+	Default RateLimit
+	Hosts   map[string]RateLimit
+}
+
+// Get returns the rate limit for the given host. The host can be provided
+// with or without port.
+func (s RateLimitSettings) Get(host string, hostname string) RateLimit {
+	if limit, found := s.Hosts[host]; found {
+		return limit
+	}
+	if limit, found := s.Hosts[hostname]; found {
+		return limit
+	}
+	return s.Default
+}
+
+func NewRateLimit(requestsPerSecond float64, burst int) *RateLimit {
+	return &RateLimit{requestsPerSecond, burst}
+}
+
+func NewRateLimitSettings(defaultLimit RateLimit, hosts map[string]RateLimit) *RateLimitSettings {
+	return &RateLimitSettings{defaultLimit, hosts}
+}
@@ -0,0 +1,88 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var rateLimiterLock sync.Mutex
+var rateLimiters = map[string]*rateLimiter{}
+
+// rateLimiter implements a token bucket which is shared by all HttpClients
+// in the process sending requests to the same host.
+//
+// The bucket starts full with burst tokens and is refilled with the
+// configured rate. Every request takes a token and waits in case the bucket
+// is empty.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Wait blocks until the request is allowed to be sent or the context is
+// cancelled.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = math.Max(1, math.Ceil(limit.RequestsPerSecond))
+	}
+	return &rateLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// sharedRateLimiter returns the process-wide rate limiter for the given host
+// or nil in case the requests are not limited.
+func sharedRateLimiter(host string, limit RateLimit) *rateLimiter {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s|%g|%d", host, limit.RequestsPerSecond, limit.Burst)
+	rateLimiterLock.Lock()
+	defer rateLimiterLock.Unlock()
+	limiter, found := rateLimiters[key]
+	if !found {
+		limiter = newRateLimiter(limit)
+		rateLimiters[key] = limiter
+	}
+	return limiter
+}
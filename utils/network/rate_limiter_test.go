@@ -0,0 +1,87 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllowsBurst(t *testing.T) {
+	limiter := newRateLimiter(*NewRateLimit(1, 3))
+	now := limiter.last
+
+	delays := []time.Duration{limiter.reserve(now), limiter.reserve(now), limiter.reserve(now)}
+
+	for _, delay := range delays {
+		if delay != 0 {
+			t.Errorf("Expected burst requests without delay, got: %v", delays)
+		}
+	}
+}
+
+func TestRateLimiterDelaysRequestsExceedingBurst(t *testing.T) {
+	limiter := newRateLimiter(*NewRateLimit(2, 1))
+	now := limiter.last
+
+	first := limiter.reserve(now)
+	second := limiter.reserve(now)
+	third := limiter.reserve(now)
+
+	if first != 0 || second != 500*time.Millisecond || third != 1*time.Second {
+		t.Errorf("Expected delays 0s, 500ms, 1s, got: %v, %v, %v", first, second, third)
+	}
+}
+
+func TestRateLimiterRefillsTokens(t *testing.T) {
+	limiter := newRateLimiter(*NewRateLimit(10, 1))
+	now := limiter.last
+
+	limiter.reserve(now)
+	delay := limiter.reserve(now.Add(100 * time.Millisecond))
+
+	if delay != 0 {
+		t.Errorf("Expected refilled token without delay, got: %v", delay)
+	}
+}
+
+func TestRateLimiterDefaultBurstIsRate(t *testing.T) {
+	limiter := newRateLimiter(*NewRateLimit(5, 0))
+
+	if limiter.burst != 5 {
+		t.Errorf("Expected burst to default to rate, got: %v", limiter.burst)
+	}
+}
+
+func TestSharedRateLimiterIsReusedForSameHost(t *testing.T) {
+	limit := *NewRateLimit(100, 10)
+
+	first := sharedRateLimiter("shared.example.com", limit)
+	second := sharedRateLimiter("shared.example.com", limit)
+	other := sharedRateLimiter("other.example.com", limit)
+
+	if first != second {
+		t.Errorf("Expected same rate limiter for same host")
+	}
+	if first == other {
+		t.Errorf("Expected different rate limiter for other host")
+	}
+}
+
+func TestSharedRateLimiterDisabledWithoutRate(t *testing.T) {
+	limiter := sharedRateLimiter("disabled.example.com", RateLimit{})
+
+	if limiter != nil {
+		t.Errorf("Expected no rate limiter, got: %v", limiter)
+	}
+}
+
+func TestRateLimitSettingsUsesHostOverride(t *testing.T) {
+	settings := NewRateLimitSettings(*NewRateLimit(10, 10), map[string]RateLimit{
+		"cloud.uipath.com": *NewRateLimit(1, 1),
+	})
+
+	limit := settings.Get("cloud.uipath.com:443", "cloud.uipath.com")
+
+	if limit.RequestsPerSecond != 1 || limit.Burst != 1 {
+		t.Errorf("Expected host rate limit, got: %v", limit)
+	}
+}
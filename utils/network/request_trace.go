@@ -0,0 +1,169 @@
+package network
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// requestTrace records the timings of a single HTTP request attempt using
+// httptrace and adds the request span with its phases to the tracer.
+type requestTrace struct {
+	mutex        sync.Mutex
+	tracer       *Tracer
+	method       string
+	url          string
+	attempt      int
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	end          time.Time
+	statusCode   int
+	err          error
+}
+
+func (t *requestTrace) record(timestamp *time.Time) func() {
+	return func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		if timestamp.IsZero() {
+			*timestamp = time.Now()
+		}
+	}
+}
+
+// ClientTrace returns the httptrace hooks recording the request phases.
+func (t *requestTrace) ClientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.record(&t.dnsStart)() },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.record(&t.dnsDone)() },
+		ConnectStart:         func(string, string) { t.record(&t.connectStart)() },
+		ConnectDone:          func(string, string, error) { t.record(&t.connectDone)() },
+		TLSHandshakeStart:    t.record(&t.tlsStart),
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.record(&t.tlsDone)() },
+		GotConn:              func(httptrace.GotConnInfo) { t.record(&t.gotConn)() },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.record(&t.wroteRequest)() },
+		GotFirstResponseByte: t.record(&t.firstByte),
+	}
+}
+
+func (t *requestTrace) duration(start time.Time, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// Finish ends the request span and adds a child span for every phase of
+// the request.
+func (t *requestTrace) Finish(statusCode int, err error) {
+	t.mutex.Lock()
+	t.end = time.Now()
+	t.statusCode = statusCode
+	t.err = err
+	t.mutex.Unlock()
+
+	span := t.tracer.startSpan(t.method, spanKindClient, t.tracer.root, t.start)
+	span.end = t.end
+	span.failed = err != nil || statusCode >= 400
+	span.attributes["http.request.method"] = t.method
+	span.attributes["url.full"] = t.url
+	if uri, err := url.Parse(t.url); err == nil {
+		span.attributes["server.address"] = uri.Hostname()
+	}
+	if statusCode != 0 {
+		span.attributes["http.response.status_code"] = statusCode
+	}
+	if t.attempt > 1 {
+		span.attributes["http.request.resend_count"] = t.attempt - 1
+	}
+	if err != nil {
+		span.attributes["error.type"] = err.Error()
+	}
+	t.addPhase(span, "dns", t.dnsStart, t.dnsDone)
+	t.addPhase(span, "connect", t.connectStart, t.connectDone)
+	t.addPhase(span, "tls", t.tlsStart, t.tlsDone)
+	t.addPhase(span, "send", t.gotConn, t.wroteRequest)
+	t.addPhase(span, "wait", t.wroteRequest, t.firstByte)
+	t.addPhase(span, "transfer", t.firstByte, t.end)
+}
+
+func (t *requestTrace) addPhase(parent *span, name string, start time.Time, end time.Time) {
+	if start.IsZero() || end.IsZero() {
+		return
+	}
+	span := t.tracer.startSpan(name, spanKindInternal, parent, start)
+	span.end = end
+}
+
+// Summary returns the timing breakdown of the request.
+func (t *requestTrace) Summary() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	status := fmt.Sprint(t.statusCode)
+	if t.err != nil {
+		status = "failed"
+	}
+	return fmt.Sprintf("%s %s %s (attempt %d): dns %v, connect %v, tls %v, send %v, ttfb %v, transfer %v, total %v",
+		t.method,
+		t.url,
+		status,
+		t.attempt,
+		t.round(t.duration(t.dnsStart, t.dnsDone)),
+		t.round(t.duration(t.connectStart, t.connectDone)),
+		t.round(t.duration(t.tlsStart, t.tlsDone)),
+		t.round(t.duration(t.gotConn, t.wroteRequest)),
+		t.round(t.duration(t.start, t.firstByte)),
+		t.round(t.duration(t.firstByte, t.end)),
+		t.round(t.duration(t.start, t.end)))
+}
+
+func (t *requestTrace) round(duration time.Duration) time.Duration {
+	return duration.Round(time.Microsecond)
+}
+
+func newRequestTrace(tracer *Tracer, request *HttpRequest, attempt int) *requestTrace {
+	return &requestTrace{
+		tracer:  tracer,
+		method:  request.Method,
+		url:     request.URL,
+		attempt: attempt,
+		start:   time.Now(),
+	}
+}
+
+// tracedReader finishes the request trace once the response body has been
+// read completely or is closed.
+type tracedReader struct {
+	reader io.ReadCloser
+	once   sync.Once
+	finish func()
+}
+
+func (r *tracedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err == io.EOF {
+		r.once.Do(r.finish)
+	}
+	return n, err
+}
+
+func (r *tracedReader) Close() error {
+	r.once.Do(r.finish)
+	return r.reader.Close()
+}
+
+func newTracedReader(reader io.ReadCloser, finish func()) *tracedReader {
+	return &tracedReader{reader: reader, finish: finish}
+}
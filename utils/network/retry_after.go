@@ -0,0 +1,34 @@
+package network
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseRetryAfter returns the duration to wait as requested by the server in
+// the Retry-After header. The header either contains the number of seconds or
+// an HTTP date. The duration is limited to maxDelay so that a misbehaving
+// server cannot block the CLI for an unreasonable amount of time.
+func parseRetryAfter(header http.Header, now time.Time, maxDelay time.Duration) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		if seconds > int64(maxDelay/time.Second) {
+			return maxDelay
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	date, err := http.ParseTime(value)
+	if err != nil || date.Before(now) {
+		return 0
+	}
+	return min(date.Sub(now), maxDelay)
+}
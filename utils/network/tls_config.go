@@ -0,0 +1,40 @@
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// newTlsConfig creates the TLS configuration including the custom CA
+// certificates and the client certificate from the connection settings.
+func newTlsConfig(insecure bool, settings ConnectionSettings) (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: insecure} //nolint:gosec // This is user configurable and disabled by default
+	if settings.CaCertFile != "" {
+		data, err := os.ReadFile(settings.CaCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA certificate file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("Invalid CA certificate file '%s': no PEM certificates found", settings.CaCertFile)
+		}
+		config.RootCAs = pool
+	}
+	if settings.ClientCertFile != "" || settings.ClientKeyFile != "" {
+		keyFile := settings.ClientKeyFile
+		if keyFile == "" {
+			keyFile = settings.ClientCertFile
+		}
+		certificate, err := tls.LoadX509KeyPair(settings.ClientCertFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
@@ -0,0 +1,123 @@
+package network
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UiPath/uipathcli/log"
+	"github.com/UiPath/uipathcli/utils/resiliency"
+)
+
+func TestTlsTrustsCustomCaCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caCertFile := writePemFile(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	client := newConnectionTestClient(*NewConnectionSettings("", "", caCertFile, "", "", TransportSettings{}))
+	_, err := client.Send(NewHttpRequest(http.MethodGet, server.URL, nil, http.Header{}, http.NoBody, 0))
+
+	if err != nil {
+		t.Errorf("Expected request to succeed with custom CA certificate, got: %v", err)
+	}
+}
+
+func TestTlsRejectsUnknownCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := newConnectionTestClient(ConnectionSettings{})
+	_, err := client.Send(NewHttpRequest(http.MethodGet, server.URL, nil, http.Header{}, http.NoBody, 0))
+
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("Expected certificate error, got: %v", err)
+	}
+}
+
+func TestTlsSendsClientCertificate(t *testing.T) {
+	var clientCertificates []*x509.Certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientCertificates = r.TLS.PeerCertificates
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert} //nolint:gosec // Test server
+	server.StartTLS()
+	defer server.Close()
+
+	caCertFile := writePemFile(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	certificate, key := createClientCertificate(t)
+	clientCertFile := writePemFile(t, "client.pem", "CERTIFICATE", certificate)
+	clientKeyFile := writePemFile(t, "client-key.pem", "PRIVATE KEY", key)
+
+	client := newConnectionTestClient(*NewConnectionSettings("", "", caCertFile, clientCertFile, clientKeyFile, TransportSettings{}))
+	_, err := client.Send(NewHttpRequest(http.MethodGet, server.URL, nil, http.Header{}, http.NoBody, 0))
+
+	if err != nil {
+		t.Fatalf("Expected request to succeed with client certificate, got: %v", err)
+	}
+	if len(clientCertificates) != 1 || clientCertificates[0].Subject.CommonName != "uipathcli-test" {
+		t.Errorf("Expected client certificate to be sent, got: %v", clientCertificates)
+	}
+}
+
+func TestTlsInvalidCaCertificateFileReturnsError(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	_ = os.WriteFile(caCertFile, []byte("invalid"), 0600)
+
+	_, err := newTlsConfig(false, *NewConnectionSettings("", "", caCertFile, "", "", TransportSettings{}))
+
+	expected := "Invalid CA certificate file '" + caCertFile + "': no PEM certificates found"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %v, got: %v", expected, err)
+	}
+}
+
+func newConnectionTestClient(connection ConnectionSettings) *HttpClient {
+	settings := NewHttpClientSettings(false, "", map[string]string{}, 10*time.Second, *resiliency.NewDefaultRetryPolicy(1), false, RateLimitSettings{}, connection, nil, false)
+	return NewHttpClient(log.NewDefaultLogger(io.Discard), *settings)
+}
+
+func createClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "uipathcli-test"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %v", err)
+	}
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error encoding key: %v", err)
+	}
+	return certificate, privateKey
+}
+
+func writePemFile(t *testing.T, name string, blockType string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	content := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data})
+	err := os.WriteFile(path, content, 0600)
+	if err != nil {
+		t.Fatalf("Error writing file '%s': %v", path, err)
+	}
+	return path
+}
@@ -0,0 +1,160 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/UiPath/uipathcli/utils"
+)
+
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+
+	spanStatusUnset = 0
+	spanStatusError = 2
+)
+
+// The Tracer collects the spans of all HTTP requests sent while executing a
+// command, including token requests and the requests sent by plugins.
+//
+// The spans can be exported in the OpenTelemetry (OTLP) JSON format. In
+// addition, the tracer can print a timing summary of every request on
+// standard error.
+type Tracer struct {
+	mutex   sync.Mutex
+	print   bool
+	traceId string
+	root    *span
+	spans   []*span
+}
+
+type span struct {
+	spanId       string
+	parentSpanId string
+	name         string
+	kind         int
+	start        time.Time
+	end          time.Time
+	attributes   map[string]interface{}
+	failed       bool
+}
+
+// Print returns true in case the request timings should be printed.
+func (t *Tracer) Print() bool {
+	return t.print
+}
+
+func (t *Tracer) startSpan(name string, kind int, parent *span, start time.Time) *span {
+	parentSpanId := ""
+	if parent != nil {
+		parentSpanId = parent.spanId
+	}
+	span := &span{
+		spanId:       randomHex(8),
+		parentSpanId: parentSpanId,
+		name:         name,
+		kind:         kind,
+		start:        start,
+		attributes:   map[string]interface{}{},
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = append(t.spans, span)
+	return span
+}
+
+// Finish ends the root span of the command.
+func (t *Tracer) Finish(err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.root.end = time.Now()
+	t.root.failed = err != nil
+}
+
+// Export writes all spans in the OpenTelemetry (OTLP) JSON format.
+func (t *Tracer) Export(writer io.Writer) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	spans := []otlpSpan{}
+	for _, span := range t.spans {
+		spans = append(spans, t.convertSpan(*span))
+	}
+	data := otlpTraceData{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{newOtlpAttribute("service.name", "uipathcli")},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "uipathcli", Version: utils.Version},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(data)
+	if err != nil {
+		return fmt.Errorf("Error writing trace: %w", err)
+	}
+	return nil
+}
+
+func (t *Tracer) convertSpan(span span) otlpSpan {
+	end := span.end
+	if end.IsZero() {
+		end = span.start
+	}
+	keys := []string{}
+	for key := range span.attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attributes := []otlpAttribute{}
+	for _, key := range keys {
+		attributes = append(attributes, newOtlpAttribute(key, span.attributes[key]))
+	}
+	status := otlpStatus{Code: spanStatusUnset}
+	if span.failed {
+		status.Code = spanStatusError
+	}
+	return otlpSpan{
+		TraceId:           t.traceId,
+		SpanId:            span.spanId,
+		ParentSpanId:      span.parentSpanId,
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(end.UnixNano(), 10),
+		Attributes:        attributes,
+		Status:            status,
+	}
+}
+
+func randomHex(size int) string {
+	data := make([]byte, size)
+	_, _ = rand.Read(data)
+	return hex.EncodeToString(data)
+}
+
+// NewTracer creates a tracer with a root span for the given command name.
+func NewTracer(name string, print bool) *Tracer {
+	tracer := &Tracer{
+		print:   print,
+		traceId: randomHex(16),
+	}
+	tracer.root = tracer.startSpan(name, spanKindInternal, nil, time.Now())
+	return tracer
+}
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const DefaultMaxIdleConns = 100
+const DefaultMaxIdleConnsPerHost = 10
+const DefaultIdleConnTimeout = 90 * time.Second
+const DefaultTLSHandshakeTimeout = 10 * time.Second
+
+// The TransportSettings define how many idle connections are kept open for
+// reuse and how long they stay open. Zero values use the defaults.
+type TransportSettings struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+func (s TransportSettings) withDefaults() TransportSettings {
+	if s.MaxIdleConns <= 0 {
+		s.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if s.MaxIdleConnsPerHost <= 0 {
+		s.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if s.IdleConnTimeout <= 0 {
+		s.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	return s
+}
+
+func NewTransportSettings(maxIdleConns int, maxIdleConnsPerHost int, idleConnTimeout time.Duration) *TransportSettings {
+	return &TransportSettings{maxIdleConns, maxIdleConnsPerHost, idleConnTimeout}
+}
+
+// The transportKey contains the client settings which require a separate
+// transport. Clients with the same settings share the transport and its
+// connections.
+type transportKey struct {
+	insecure   bool
+	timeout    time.Duration
+	connection ConnectionSettings
+}
+
+// transportPool keeps one transport per distinct client settings for the
+// lifetime of the process so that connections are kept alive and reused
+// across requests, retries, pages and polling loops.
+type transportPool struct {
+	mutex      sync.Mutex
+	transports map[transportKey]*http.Transport
+}
+
+var sharedTransportPool = newTransportPool()
+
+// Get returns the transport for the given client settings and creates it in
+// case it does not exist yet.
+func (p *transportPool) Get(settings HttpClientSettings) (*http.Transport, error) {
+	key := transportKey{settings.Insecure, settings.Timeout, settings.Connection}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	transport, found := p.transports[key]
+	if found {
+		return transport, nil
+	}
+	transport, err := p.newTransport(key)
+	if err != nil {
+		return nil, err
+	}
+	p.transports[key] = transport
+	return transport, nil
+}
+
+func (p *transportPool) newTransport(key transportKey) (*http.Transport, error) {
+	proxy, err := newProxyFunc(key.connection)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTlsConfig(key.insecure, key.connection)
+	if err != nil {
+		return nil, err
+	}
+	settings := key.connection.Transport.withDefaults()
+	return &http.Transport{
+		Proxy:                 proxy,
+		TLSClientConfig:       tlsConfig,
+		ResponseHeaderTimeout: key.timeout,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          settings.MaxIdleConns,
+		MaxIdleConnsPerHost:   settings.MaxIdleConnsPerHost,
+		IdleConnTimeout:       settings.IdleConnTimeout,
+		TLSHandshakeTimeout:   DefaultTLSHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}, nil
+}
+
+func newTransportPool() *transportPool {
+	return &transportPool{
+		transports: map[transportKey]*http.Transport{},
+	}
+}
@@ -3,6 +3,7 @@
 package resiliency
 
 import (
+	"context"
 	"errors"
 	"time"
 )
@@ -16,14 +17,38 @@ func Retry(f func(attempt int) error) error {
 
 // RetryN retries the given function up to n times when it returns an RetryableError.
 func RetryN(maxAttempts int, f func(attempt int) error) error {
+	return RetryWithPolicy(*NewRetryPolicy(maxAttempts, 1*time.Second, 1*time.Second, 1, 0), f)
+}
+
+// RetryWithPolicy retries the given function when it returns an RetryableError
+// and waits between the attempts as defined by the retry policy. The delay is
+// extended in case the RetryableError asks to wait longer, up to the MaxDelay
+// of the retry policy.
+func RetryWithPolicy(policy RetryPolicy, f func(attempt int) error) error {
+	return RetryWithContext(policy, context.Background(), f)
+}
+
+// RetryWithContext retries the given function like RetryWithPolicy but stops
+// waiting for the next attempt as soon as the context is cancelled.
+func RetryWithContext(policy RetryPolicy, ctx context.Context, f func(attempt int) error) error {
 	var err error
 	for i := 1; ; i++ {
 		err = f(i)
 		var retryableError *RetryableError
 		retryable := errors.As(err, &retryableError)
-		if !retryable || i == maxAttempts {
+		if !retryable || i >= policy.MaxAttempts || ctx.Err() != nil {
 			return err
 		}
-		time.Sleep(1 * time.Second)
+		delay := policy.Delay(i)
+		if retryableError.after > delay {
+			delay = min(retryableError.after, max(delay, policy.MaxDelay))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return context.Cause(ctx)
+		case <-timer.C:
+		}
 	}
 }
@@ -0,0 +1,59 @@
+package resiliency
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const DefaultInitialDelay = 1 * time.Second
+const DefaultMaxDelay = 30 * time.Second
+const DefaultMultiplier = 2.0
+const DefaultJitter = 0.2
+
+// The RetryPolicy defines how often a failed operation is retried and how
+// long to wait between the attempts.
+//
+// The delay starts with InitialDelay and grows by Multiplier after every
+// attempt until it reaches MaxDelay. Jitter randomizes the delay by the given
+// fraction, e.g. 0.2 waits between 80% and 120% of the calculated delay.
+type RetryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+}
+
+// Delay returns the time to wait after the given failed attempt.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay = delay * (1 + p.Jitter*(2*rand.Float64()-1)) //nolint:gosec // Jitter does not need a secure random number generator
+	}
+	return time.Duration(delay)
+}
+
+func NewRetryPolicy(
+	maxAttempts int,
+	initialDelay time.Duration,
+	maxDelay time.Duration,
+	multiplier float64,
+	jitter float64) *RetryPolicy {
+	return &RetryPolicy{
+		maxAttempts,
+		initialDelay,
+		maxDelay,
+		multiplier,
+		jitter,
+	}
+}
+
+// NewDefaultRetryPolicy creates a retry policy with exponential backoff and
+// jitter using the default settings.
+func NewDefaultRetryPolicy(maxAttempts int) *RetryPolicy {
+	return NewRetryPolicy(maxAttempts, DefaultInitialDelay, DefaultMaxDelay, DefaultMultiplier, DefaultJitter)
+}
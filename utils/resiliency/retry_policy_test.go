@@ -0,0 +1,125 @@
+package resiliency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDelayGrowsExponentially(t *testing.T) {
+	policy := NewRetryPolicy(5, 1*time.Second, 30*time.Second, 2, 0)
+
+	delays := []time.Duration{policy.Delay(1), policy.Delay(2), policy.Delay(3), policy.Delay(4)}
+
+	expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for i := range expected {
+		if delays[i] != expected[i] {
+			t.Errorf("Expected delays %v, got: %v", expected, delays)
+		}
+	}
+}
+
+func TestDelayIsLimitedToMaxDelay(t *testing.T) {
+	policy := NewRetryPolicy(10, 1*time.Second, 5*time.Second, 2, 0)
+
+	delay := policy.Delay(8)
+
+	if delay != 5*time.Second {
+		t.Errorf("Expected max delay, got: %v", delay)
+	}
+}
+
+func TestDelayAddsJitter(t *testing.T) {
+	policy := NewRetryPolicy(3, 1*time.Second, 30*time.Second, 2, 0.5)
+
+	for i := 0; i < 100; i++ {
+		delay := policy.Delay(1)
+		if delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+			t.Errorf("Expected delay between 500ms and 1.5s, got: %v", delay)
+		}
+	}
+}
+
+func TestRetryWithPolicyStopsAfterMaxAttempts(t *testing.T) {
+	policy := NewRetryPolicy(3, 1*time.Millisecond, 1*time.Millisecond, 1, 0)
+
+	attempts := 0
+	err := RetryWithPolicy(*policy, func(attempt int) error {
+		attempts = attempt
+		return Retryable(errors.New("failed"))
+	})
+
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("Expected error, got: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got: %v", attempts)
+	}
+}
+
+func TestRetryWithPolicyDoesNotRetryNonRetryableErrors(t *testing.T) {
+	policy := NewRetryPolicy(3, 1*time.Millisecond, 1*time.Millisecond, 1, 0)
+
+	attempts := 0
+	_ = RetryWithPolicy(*policy, func(attempt int) error {
+		attempts = attempt
+		return errors.New("failed")
+	})
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got: %v", attempts)
+	}
+}
+
+func TestRetryWithPolicyWaitsForRetryableAfter(t *testing.T) {
+	policy := NewRetryPolicy(2, 1*time.Millisecond, 1*time.Second, 1, 0)
+
+	start := time.Now()
+	_ = RetryWithPolicy(*policy, func(attempt int) error {
+		return RetryableAfter(errors.New("failed"), 100*time.Millisecond)
+	})
+
+	elapsed := time.Since(start)
+	if elapsed < 100*time.Millisecond {
+		t.Errorf("Expected to wait at least 100ms, waited: %v", elapsed)
+	}
+}
+
+func TestRetryWithPolicyLimitsRetryableAfterToMaxDelay(t *testing.T) {
+	policy := NewRetryPolicy(2, 1*time.Millisecond, 10*time.Millisecond, 1, 0)
+
+	start := time.Now()
+	_ = RetryWithPolicy(*policy, func(attempt int) error {
+		return RetryableAfter(errors.New("failed"), 1*time.Hour)
+	})
+
+	elapsed := time.Since(start)
+	if elapsed > 1*time.Second {
+		t.Errorf("Expected to wait at most the max delay, waited: %v", elapsed)
+	}
+}
+
+func TestRetryWithContextStopsWaitingWhenCancelled(t *testing.T) {
+	policy := NewRetryPolicy(2, 1*time.Hour, 1*time.Hour, 1, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	start := time.Now()
+	err := RetryWithContext(*policy, ctx, func(attempt int) error {
+		attempts = attempt
+		return Retryable(errors.New("failed"))
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded error, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got: %v", attempts)
+	}
+	elapsed := time.Since(start)
+	if elapsed > 1*time.Second {
+		t.Errorf("Expected to stop waiting when context is cancelled, waited: %v", elapsed)
+	}
+}
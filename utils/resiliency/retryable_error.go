@@ -1,15 +1,28 @@
 package resiliency
 
+import "time"
+
 // RetryableError can be returned inside of the Retry() block to indicate
 // that the function failed and should be retried.
 type RetryableError struct {
-	err error
+	err   error
+	after time.Duration
 }
 
 func (e RetryableError) Error() string {
 	return e.err.Error()
 }
 
+func (e RetryableError) Unwrap() error {
+	return e.err
+}
+
 func Retryable(err error) *RetryableError {
-	return &RetryableError{err}
+	return &RetryableError{err, 0}
+}
+
+// RetryableAfter indicates that the function failed and should be retried
+// after waiting at least the given duration.
+func RetryableAfter(err error, after time.Duration) *RetryableError {
+	return &RetryableError{err, after}
 }